@@ -0,0 +1,201 @@
+package cbweb
+
+import (
+	"encoding/json"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/valyala/fasthttp"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type AccessLogFormat string
+
+var (
+	AccessLogFormat_Json   AccessLogFormat = "json"
+	AccessLogFormat_Logfmt AccessLogFormat = "logfmt"
+)
+
+type AccessLogRecord struct {
+	Time       time.Time
+	Method     string
+	Path       string
+	Status     int
+	Bytes      int
+	Duration   time.Duration
+	RemoteIp   string
+	Identifier string
+	RequestId  string
+}
+
+type AccessLogSink interface {
+	Write(record AccessLogRecord)
+}
+
+type WriterAccessLogSink struct {
+	writer io.Writer
+	format AccessLogFormat
+	lock   sync.Mutex
+}
+
+func NewWriterAccessLogSink(writer io.Writer, format AccessLogFormat) *WriterAccessLogSink {
+	if format == "" {
+		format = AccessLogFormat_Json
+	}
+
+	return &WriterAccessLogSink{
+		writer: writer,
+		format: format,
+	}
+}
+
+func (w *WriterAccessLogSink) Write(record AccessLogRecord) {
+	var line []byte
+	if w.format == AccessLogFormat_Logfmt {
+		line = formatAccessLogLogfmt(record)
+	} else {
+		line = formatAccessLogJson(record)
+	}
+
+	w.lock.Lock()
+	_, _ = w.writer.Write(line)
+	w.lock.Unlock()
+}
+
+func getAccessLogFields(record AccessLogRecord) [][2]string {
+	return [][2]string{
+		{"time", record.Time.UTC().Format(time.RFC3339Nano)},
+		{"method", record.Method},
+		{"path", record.Path},
+		{"status", strconv.Itoa(record.Status)},
+		{"bytes", strconv.Itoa(record.Bytes)},
+		{"duration_ms", strconv.FormatFloat(float64(record.Duration)/float64(time.Millisecond), 'f', 3, 64)},
+		{"remote_ip", record.RemoteIp},
+		{"identifier", record.Identifier},
+		{"request_id", record.RequestId},
+	}
+}
+
+func formatAccessLogJson(record AccessLogRecord) []byte {
+	line := []byte{'{'}
+	for key, field := range getAccessLogFields(record) {
+		if key > 0 {
+			line = append(line, ',')
+		}
+		name, _ := json.Marshal(field[0])
+		line = append(line, name...)
+		line = append(line, ':')
+		switch field[0] {
+		case "status", "bytes", "duration_ms":
+			line = append(line, field[1]...)
+		default:
+			value, _ := json.Marshal(field[1])
+			line = append(line, value...)
+		}
+	}
+
+	return append(line, '}', '\n')
+}
+
+func formatAccessLogLogfmt(record AccessLogRecord) []byte {
+	var line []byte
+	for key, field := range getAccessLogFields(record) {
+		if key > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, field[0]...)
+		line = append(line, '=')
+		if field[1] == "" || strings.ContainsAny(field[1], " =\"\\") || strings.IndexFunc(field[1], isControlRune) != -1 {
+			line = strconv.AppendQuote(line, field[1])
+		} else {
+			line = append(line, field[1]...)
+		}
+	}
+
+	return append(line, '\n')
+}
+
+func isControlRune(r rune) bool {
+	return r < ' ' || r == 0x7f
+}
+
+type AccessLogConfig struct {
+	// Sink defaults to json lines on stdout
+	Sink AccessLogSink
+	// TrustedProxies are believed about the client's ip, without them the connection's ip is logged
+	TrustedProxies *TrustedProxies
+	// Auth is used to fill in the identifier of the user making the request
+	Auth *cbwebauth.Container
+}
+
+type AccessLog struct {
+	sink           AccessLogSink
+	trustedProxies *TrustedProxies
+	auth           *cbwebauth.Container
+}
+
+func NewAccessLog(config AccessLogConfig) *AccessLog {
+	if config.Sink == nil {
+		config.Sink = NewWriterAccessLogSink(os.Stdout, AccessLogFormat_Json)
+	}
+
+	return &AccessLog{
+		sink:           config.Sink,
+		trustedProxies: config.TrustedProxies,
+		auth:           config.Auth,
+	}
+}
+
+// Handler wraps next so one record is written for every request it handles, including ones that panic
+func (a *AccessLog) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		start := time.Now()
+		method := string(ctx.Method())
+		path := string(ctx.Path())
+		remoteIp := GetRemoteIp(ctx, a.trustedProxies)
+
+		defer func() {
+			response := getResponse(ctx)
+			record := AccessLogRecord{
+				Time:     start,
+				Method:   method,
+				Path:     path,
+				Status:   response.StatusCode(),
+				Duration: time.Since(start),
+				RemoteIp: remoteIp,
+			}
+
+			if response.IsBodyStream() {
+				record.Bytes = response.Header.ContentLength()
+				if record.Bytes < 0 {
+					record.Bytes = 0
+				}
+			} else {
+				record.Bytes = len(response.Body())
+			}
+
+			// a timed out handler may still be using the ctx, so only its timeout response is read
+			if IsTimedOut(ctx) {
+				record.RequestId = string(response.Header.Peek(RequestIdHeader))
+				a.sink.Write(record)
+				return
+			}
+
+			if a.auth != nil {
+				record.Identifier = a.auth.FindUniqueIdentifier(ctx)
+			}
+
+			record.RequestId = GetRequestId(ctx)
+			if record.RequestId == "" {
+				record.RequestId = string(ctx.Request.Header.Peek(RequestIdHeader))
+			}
+
+			a.sink.Write(record)
+		}()
+
+		next(ctx)
+	}
+}
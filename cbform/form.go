@@ -20,6 +20,8 @@ type Field struct {
 	Help                string
 	Value               interface{}
 	Options             []*Option
+	// CspNonce is set on inline scripts rendered for the field, such as inputchipsjs
+	CspNonce            string
 }
 
 type Option struct {
@@ -39,6 +41,13 @@ func New(fields ...Field) *Container {
 	return &Container{fields: mapFields}
 }
 
+func (c *Container) AddField(field Field) {
+	if c.fields == nil {
+		c.fields = make(map[string]*Field)
+	}
+	c.fields[field.Name] = &field
+}
+
 func (c *Container) GetField(fieldName string) *Field {
 	field, ok := c.fields[fieldName]
 	if ok {
@@ -160,3 +160,16 @@ func (c *Container) Logout(providerName string, ctx *fasthttp.RequestCtx, redire
 
 	return false, errors.New("auth provider not found")
 }
+
+// FindUniqueIdentifier returns the first non empty unique identifier from the configured providers,
+// it does not check the request is authenticated so it is suitable for logging but not access control
+func (c *Container) FindUniqueIdentifier(ctx *fasthttp.RequestCtx) string {
+	for _, provider := range c.providers {
+		identifier := provider.GetUniqueIdentifier(ctx)
+		if identifier != "" {
+			return identifier
+		}
+	}
+
+	return ""
+}
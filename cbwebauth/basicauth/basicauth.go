@@ -6,6 +6,8 @@ import (
 	"github.com/codingbeard/cbweb/cbwebauth"
 	"github.com/valyala/fasthttp"
 	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"time"
 )
 
 type Credential struct {
@@ -16,6 +18,8 @@ type Credential struct {
 
 type Provider struct {
 	Credentials []Credential
+	// BruteForce delays and locks out repeated failed attempts, it is optional
+	BruteForce *cbwebauth.BruteForce
 }
 
 var ProviderName = "basicauth"
@@ -50,12 +54,30 @@ func (p Provider) GetPermissions(ctx *fasthttp.RequestCtx) []string {
 
 func (p Provider) IsAuthenticated(ctx *fasthttp.RequestCtx) bool {
 	user, pass := p.getCredentials(ctx)
+	if p.BruteForce != nil && user != "" {
+		// the credentials come with every request, so a failure is only counted once the password is wrong
+		wait, e := p.BruteForce.Peek(ctx, user)
+		if e != nil {
+			ctx.Response.Header.Set("Retry-After", strconv.FormatInt(int64((wait+time.Second-1)/time.Second), 10))
+			ctx.SetStatusCode(fasthttp.StatusTooManyRequests)
+			ctx.SetBodyString(e.Error())
+			return false
+		}
+	}
+
 	for _, credential := range p.Credentials {
 		if credential.Username == user && bcrypt.CompareHashAndPassword([]byte(credential.Password), []byte(pass)) == nil {
+			if p.BruteForce != nil {
+				p.BruteForce.Success(ctx, user)
+			}
 			return true
 		}
 	}
 
+	if p.BruteForce != nil && user != "" {
+		p.BruteForce.Failure(ctx, user)
+	}
+
 	ctx.Response.Header.Set("WWW-Authenticate", "Basic realm=Restricted")
 	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
 
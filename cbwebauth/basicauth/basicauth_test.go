@@ -0,0 +1,63 @@
+package basicauth_test
+
+import (
+	"encoding/base64"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/codingbeard/cbweb/cbwebauth/basicauth"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/crypto/bcrypt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func newRequest(user, password string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234}, nil)
+
+	return ctx
+}
+
+func TestBruteForceAllowsParallelValidRequests(t *testing.T) {
+	// the default cost is slow enough for the parallel requests to overlap
+	hash, e := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+	if e != nil {
+		t.Fatal(e)
+	}
+	provider := basicauth.New(basicauth.Credential{Username: "admin", Password: string(hash)})
+	provider.BruteForce = cbwebauth.NewBruteForce(cbwebauth.BruteForceConfig{Cache: cbwebtest.NewCache()})
+
+	// a page and its assets are requested at once with the same credentials
+	var authenticated int32
+	var wg sync.WaitGroup
+	for request := 0; request < 10; request++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if provider.IsAuthenticated(newRequest("admin", "secret")) {
+				atomic.AddInt32(&authenticated, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if authenticated != 10 {
+		t.Errorf("expected all 10 requests with valid credentials to pass, %d did", authenticated)
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		ctx := newRequest("admin", "wrong")
+		if provider.IsAuthenticated(ctx) || ctx.Response.StatusCode() != fasthttp.StatusUnauthorized {
+			t.Fatalf("expected wrong password %d to get 401, got %d", attempt, ctx.Response.StatusCode())
+		}
+	}
+
+	ctx := newRequest("admin", "secret")
+	if provider.IsAuthenticated(ctx) || ctx.Response.StatusCode() != fasthttp.StatusTooManyRequests {
+		t.Errorf("expected the account to be delayed after 3 failures, got %d", ctx.Response.StatusCode())
+	}
+}
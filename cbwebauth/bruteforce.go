@@ -0,0 +1,339 @@
+package cbwebauth
+
+import (
+	"errors"
+	"github.com/valyala/fasthttp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type BruteForceEventType string
+
+var (
+	BruteForceEvent_Failure BruteForceEventType = "failure"
+	BruteForceEvent_Locked  BruteForceEventType = "locked"
+	// BruteForceEvent_Blocked is an attempt made while delayed or locked, it was not checked
+	BruteForceEvent_Blocked BruteForceEventType = "blocked"
+
+	BruteForceScope_Account = "account"
+	BruteForceScope_Ip      = "ip"
+
+	bruteForceCountedKey = "cbwebauth-brute-force-counted"
+)
+
+// CacheProvider is the same as cbweb.CacheProvider, it is repeated as cbweb imports this package
+type CacheProvider interface {
+	Get(key string) (interface{}, bool)
+	Delete(key string)
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+type BruteForceEvent struct {
+	Type BruteForceEventType
+	// Scope is the account or ip whose limit caused the event
+	Scope       string
+	Account     string
+	Ip          string
+	Failures    int
+	LockedUntil time.Time
+}
+
+type BruteForceConfig struct {
+	Cache     CacheProvider
+	KeyPrefix string
+	// AccountDelayAfter failures each further attempt has to wait BaseDelay, doubling per failure up to MaxDelay.
+	// Defaults to 3 failures, 1 second and 1 minute
+	AccountDelayAfter int
+	BaseDelay         time.Duration
+	MaxDelay          time.Duration
+	// AccountLockoutAfter failures lock the account for LockoutDuration, defaults to 10 failures and 15 minutes
+	AccountLockoutAfter int
+	// IpDelayAfter and IpLockoutAfter are higher so users behind a shared ip are not held up by one person,
+	// they default to 10 and 50
+	IpDelayAfter    int
+	IpLockoutAfter  int
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the last one, defaults to 1 hour
+	Window time.Duration
+	// GetIp returns the client's ip, pass cbweb.TrustedProxies.GetRemoteIp behind a proxy so it matches the rest
+	// of the site. Defaults to the connection's ip
+	GetIp func(ctx *fasthttp.RequestCtx) string
+	// OnEvent is called for failures, lockouts and blocked attempts, use it for alerting
+	OnEvent func(event BruteForceEvent)
+}
+
+type bruteForceAttempt struct {
+	account        string
+	ip             string
+	accountRecord  bruteForceRecord
+	accountLocked  bool
+	ipRecord       bruteForceRecord
+	ipLocked       bool
+	ipPrevious     bruteForceRecord
+	ipPreviousSeen bool
+}
+
+type bruteForceRecord struct {
+	Failures    int
+	NextAttempt time.Time
+	LockedUntil time.Time
+}
+
+// BruteForce tracks failed logins by account and ip, applying progressive delays and then lockouts.
+// Call Check before verifying a password, then Failure or Success with the result. Check counts the
+// attempt as a failure straight away so parallel guesses cannot all pass it, Success refunds it.
+// Peek checks without counting for credentials which are sent with every request
+type BruteForce struct {
+	config BruteForceConfig
+	// CacheProvider has no atomic update, the lock keeps concurrent failures in this process from being lost
+	lock sync.Mutex
+}
+
+func NewBruteForce(config BruteForceConfig) *BruteForce {
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "cbwebauth-brute-force:"
+	}
+	if config.AccountDelayAfter == 0 {
+		config.AccountDelayAfter = 3
+	}
+	if config.BaseDelay == 0 {
+		config.BaseDelay = time.Second
+	}
+	if config.MaxDelay == 0 {
+		config.MaxDelay = time.Minute
+	}
+	if config.AccountLockoutAfter == 0 {
+		config.AccountLockoutAfter = 10
+	}
+	if config.IpDelayAfter == 0 {
+		config.IpDelayAfter = 10
+	}
+	if config.IpLockoutAfter == 0 {
+		config.IpLockoutAfter = 50
+	}
+	if config.LockoutDuration == 0 {
+		config.LockoutDuration = time.Minute * 15
+	}
+	if config.Window == 0 {
+		config.Window = time.Hour
+	}
+
+	if config.GetIp == nil {
+		config.GetIp = func(ctx *fasthttp.RequestCtx) string {
+			return ctx.RemoteIP().String()
+		}
+	}
+
+	return &BruteForce{config: config}
+}
+
+// Check returns an error suitable for validationErrors["flash"] when the account or ip has to wait
+// before another attempt, the wait is also returned so it can be sent as Retry-After. Otherwise the
+// attempt is reserved as a failure until Success is called
+func (b *BruteForce) Check(ctx *fasthttp.RequestCtx, account string) (time.Duration, error) {
+	return b.check(ctx, account, true)
+}
+
+// Peek is Check without reserving the attempt, call Failure once the credentials have failed. Use it where valid
+// credentials are sent with every request, such as basic auth, as a page's parallel requests would otherwise
+// each reserve a failure and hold up the user with the right password
+func (b *BruteForce) Peek(ctx *fasthttp.RequestCtx, account string) (time.Duration, error) {
+	return b.check(ctx, account, false)
+}
+
+func (b *BruteForce) check(ctx *fasthttp.RequestCtx, account string, reserve bool) (time.Duration, error) {
+	// providers may be asked more than once per request, the attempt this request reserved must not block it
+	if ctx.UserValue(bruteForceCountedKey) != nil {
+		return 0, nil
+	}
+
+	account = strings.ToLower(account)
+	ip := b.config.GetIp(ctx)
+	now := time.Now()
+
+	b.lock.Lock()
+	accountRecord := b.getRecord(BruteForceScope_Account, account)
+	ipRecord, ipSeen := b.findRecord(BruteForceScope_Ip, ip)
+
+	accountWait, accountLocked := accountRecord.getWait(now)
+	ipWait, ipLocked := ipRecord.getWait(now)
+
+	wait, locked, scope, failures := accountWait, accountLocked, BruteForceScope_Account, accountRecord.Failures
+	if ipWait > wait {
+		wait, locked, scope, failures = ipWait, ipLocked, BruteForceScope_Ip, ipRecord.Failures
+	}
+
+	if wait <= 0 {
+		if !reserve {
+			b.lock.Unlock()
+			return 0, nil
+		}
+		attempt := &bruteForceAttempt{account: account, ip: ip, ipPrevious: ipRecord, ipPreviousSeen: ipSeen}
+		attempt.accountRecord, attempt.accountLocked = b.addFailure(BruteForceScope_Account, account, b.config.AccountDelayAfter, b.config.AccountLockoutAfter, now)
+		attempt.ipRecord, attempt.ipLocked = b.addFailure(BruteForceScope_Ip, ip, b.config.IpDelayAfter, b.config.IpLockoutAfter, now)
+		b.lock.Unlock()
+
+		ctx.SetUserValue(bruteForceCountedKey, attempt)
+		return 0, nil
+	}
+	b.lock.Unlock()
+
+	b.emit(BruteForceEvent{Type: BruteForceEvent_Blocked, Scope: scope, Account: account, Ip: ip, Failures: failures})
+	if locked {
+		return wait, errors.New("too many failed login attempts, please try again in " + formatBruteForceWait(wait))
+	}
+
+	return wait, errors.New("please wait " + formatBruteForceWait(wait) + " before trying again")
+}
+
+// Failure records a failed attempt, it is only counted once per request however many times it is called
+func (b *BruteForce) Failure(ctx *fasthttp.RequestCtx, account string) {
+	attempt, ok := ctx.UserValue(bruteForceCountedKey).(*bruteForceAttempt)
+	if ok && attempt == nil {
+		return
+	}
+	if !ok {
+		// Check was not called, so the failure has not been counted yet
+		attempt = &bruteForceAttempt{account: strings.ToLower(account), ip: b.config.GetIp(ctx)}
+		now := time.Now()
+
+		b.lock.Lock()
+		attempt.accountRecord, attempt.accountLocked = b.addFailure(BruteForceScope_Account, attempt.account, b.config.AccountDelayAfter, b.config.AccountLockoutAfter, now)
+		attempt.ipRecord, attempt.ipLocked = b.addFailure(BruteForceScope_Ip, attempt.ip, b.config.IpDelayAfter, b.config.IpLockoutAfter, now)
+		b.lock.Unlock()
+	}
+	// a nil attempt marks the failure as reported
+	ctx.SetUserValue(bruteForceCountedKey, (*bruteForceAttempt)(nil))
+
+	b.emit(BruteForceEvent{Type: BruteForceEvent_Failure, Scope: BruteForceScope_Account, Account: attempt.account, Ip: attempt.ip, Failures: attempt.accountRecord.Failures})
+	if attempt.accountLocked {
+		b.emit(BruteForceEvent{Type: BruteForceEvent_Locked, Scope: BruteForceScope_Account, Account: attempt.account, Ip: attempt.ip, Failures: attempt.accountRecord.Failures, LockedUntil: attempt.accountRecord.LockedUntil})
+	}
+	if attempt.ipLocked {
+		b.emit(BruteForceEvent{Type: BruteForceEvent_Locked, Scope: BruteForceScope_Ip, Account: attempt.account, Ip: attempt.ip, Failures: attempt.ipRecord.Failures, LockedUntil: attempt.ipRecord.LockedUntil})
+	}
+}
+
+// Success forgets the account's failures and refunds the attempt Check reserved for the ip, the ip's earlier
+// failures are kept so one valid account cannot reset them
+func (b *BruteForce) Success(ctx *fasthttp.RequestCtx, account string) {
+	attempt, _ := ctx.UserValue(bruteForceCountedKey).(*bruteForceAttempt)
+	ctx.SetUserValue(bruteForceCountedKey, (*bruteForceAttempt)(nil))
+	key := b.getKey(BruteForceScope_Account, strings.ToLower(account))
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.config.Cache.Get(key); ok {
+		b.config.Cache.Delete(key)
+	}
+	if attempt == nil {
+		return
+	}
+
+	ipKey := b.getKey(BruteForceScope_Ip, attempt.ip)
+	record, ok := b.findRecord(BruteForceScope_Ip, attempt.ip)
+	if !ok || record.Failures <= 0 {
+		return
+	}
+	record.Failures--
+	if attempt.ipLocked && record.LockedUntil.Equal(attempt.ipRecord.LockedUntil) {
+		record.LockedUntil = attempt.ipPrevious.LockedUntil
+	}
+	if record.NextAttempt.Equal(attempt.ipRecord.NextAttempt) {
+		record.NextAttempt = attempt.ipPrevious.NextAttempt
+	}
+	if record.Failures == 0 && !attempt.ipPreviousSeen {
+		b.config.Cache.Delete(ipKey)
+		return
+	}
+	b.config.Cache.Set(ipKey, record, b.config.Window)
+}
+
+func (record bruteForceRecord) getWait(now time.Time) (time.Duration, bool) {
+	if record.LockedUntil.After(now) {
+		return record.LockedUntil.Sub(now), true
+	}
+	if record.NextAttempt.After(now) {
+		return record.NextAttempt.Sub(now), false
+	}
+
+	return 0, false
+}
+
+// addFailure returns whether the failure locked the account or ip, every failure past lockoutAfter
+// locks again once the previous lockout has expired
+func (b *BruteForce) addFailure(scope, value string, delayAfter, lockoutAfter int, now time.Time) (bruteForceRecord, bool) {
+	record := b.getRecord(scope, value)
+	record.Failures++
+	locked := false
+
+	if record.Failures >= lockoutAfter {
+		if !record.LockedUntil.After(now) {
+			record.LockedUntil = now.Add(b.config.LockoutDuration)
+			locked = true
+		}
+	} else if record.Failures >= delayAfter {
+		delay := b.config.BaseDelay
+		for i := delayAfter; i < record.Failures && delay < b.config.MaxDelay; i++ {
+			delay *= 2
+		}
+		if delay > b.config.MaxDelay {
+			delay = b.config.MaxDelay
+		}
+		record.NextAttempt = now.Add(delay)
+	}
+
+	ttl := b.config.Window
+	if record.LockedUntil.After(now) && record.LockedUntil.Sub(now) > ttl {
+		ttl = record.LockedUntil.Sub(now)
+	}
+	b.config.Cache.Set(b.getKey(scope, value), record, ttl)
+
+	return record, locked
+}
+
+func (b *BruteForce) getRecord(scope, value string) bruteForceRecord {
+	record, _ := b.findRecord(scope, value)
+
+	return record
+}
+
+func (b *BruteForce) findRecord(scope, value string) (bruteForceRecord, bool) {
+	stored, ok := b.config.Cache.Get(b.getKey(scope, value))
+	if !ok {
+		return bruteForceRecord{}, false
+	}
+	record, ok := stored.(bruteForceRecord)
+	if !ok {
+		return bruteForceRecord{}, false
+	}
+
+	return record, true
+}
+
+func (b *BruteForce) getKey(scope, value string) string {
+	return b.config.KeyPrefix + scope + ":" + value
+}
+
+func (b *BruteForce) emit(event BruteForceEvent) {
+	if b.config.OnEvent != nil {
+		b.config.OnEvent(event)
+	}
+}
+
+func formatBruteForceWait(wait time.Duration) string {
+	if wait <= time.Minute {
+		seconds := int((wait + time.Second - 1) / time.Second)
+		if seconds == 1 {
+			return "1 second"
+		}
+		return strconv.Itoa(seconds) + " seconds"
+	}
+
+	minutes := int((wait + time.Minute - 1) / time.Minute)
+
+	return strconv.Itoa(minutes) + " minutes"
+}
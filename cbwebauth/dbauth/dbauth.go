@@ -3,6 +3,7 @@ package dbauth
 import (
 	"bytes"
 	"errors"
+	"github.com/codingbeard/cbweb"
 	"github.com/codingbeard/cbweb/cbwebauth"
 	"github.com/codingbeard/checkmail"
 	"github.com/golang-jwt/jwt"
@@ -21,6 +22,8 @@ type Provider struct {
 	generateAuthHashFunc func(user UserRecord) string
 	hashWorkFactor       int
 	saveUserRecordFunc   func(user UserRecord) error
+	bruteForce           *cbwebauth.BruteForce
+	csrf                 *cbweb.Csrf
 }
 
 type GormReadWrite interface {
@@ -49,6 +52,11 @@ type Dependencies struct {
 	SaveUserRecordFunc   func(user UserRecord) error
 	GenerateAuthHashFunc func(user UserRecord) string
 	HashWorkFactor       int
+	// BruteForce delays and locks out repeated failed logins, it is optional
+	BruteForce *cbwebauth.BruteForce
+	// Csrf rejects login, logout, register and change password posts without the form's token, render
+	// DefaultMasterViewModel.CsrfField in the forms. It is optional
+	Csrf *cbweb.Csrf
 }
 
 type UserClaim struct {
@@ -75,6 +83,8 @@ func New(dependencies Dependencies) (*Provider, error) {
 		generateAuthHashFunc: dependencies.GenerateAuthHashFunc,
 		hashWorkFactor:       dependencies.HashWorkFactor,
 		saveUserRecordFunc:   dependencies.SaveUserRecordFunc,
+		bruteForce:           dependencies.BruteForce,
+		csrf:                 dependencies.Csrf,
 	}
 
 	return auth, nil
@@ -192,6 +202,10 @@ func (a *Provider) IsAuthenticated(ctx *fasthttp.RequestCtx) bool {
 func (a *Provider) Login(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
 	post := ctx.Request.PostArgs()
 	if post != nil && post.Len() > 0 {
+		if !a.verifyCsrf(ctx) {
+			return false, csrfErrors()
+		}
+
 		validationErrors := make(map[string]error)
 
 		if !post.Has("email") {
@@ -210,19 +224,36 @@ func (a *Provider) Login(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
 			return false, validationErrors
 		}
 
+		email := string(post.Peek("email"))
+		if a.bruteForce != nil {
+			_, e := a.bruteForce.Check(ctx, email)
+			if e != nil {
+				return false, map[string]error{"flash": e}
+			}
+		}
+
 		for _, user := range a.getUserRecordsFunc() {
-			if strings.ToLower(user.GetEmail()) == strings.ToLower(string(post.Peek("email"))) {
+			if strings.ToLower(user.GetEmail()) == strings.ToLower(email) {
 				if bcrypt.CompareHashAndPassword([]byte(user.GetPassword()), post.Peek("password")) == nil {
+					if a.bruteForce != nil {
+						a.bruteForce.Success(ctx, email)
+					}
 					e := a.SetAuthCookie(ctx, user)
 					if e != nil {
 						return false, map[string]error{"flash": errors.New("error setting auth cookie")}
 					}
 					return true, validationErrors
 				} else {
+					if a.bruteForce != nil {
+						a.bruteForce.Failure(ctx, email)
+					}
 					return false, map[string]error{"password": errors.New("invalid password")}
 				}
 			}
 		}
+		if a.bruteForce != nil {
+			a.bruteForce.Failure(ctx, email)
+		}
 		return false, map[string]error{"email": errors.New("user not found")}
 	} else if ctx.Request.URI().QueryArgs().Has("dbauthtoken") {
 		user := a.getUserFromLoginToken(string(ctx.Request.URI().QueryArgs().Peek("dbauthtoken")))
@@ -256,6 +287,10 @@ func (a *Provider) SetAuthCookie(ctx *fasthttp.RequestCtx, user UserRecord) erro
 }
 
 func (a *Provider) Logout(ctx *fasthttp.RequestCtx) bool {
+	if !a.verifyCsrf(ctx) {
+		return false
+	}
+
 	var cookie fasthttp.Cookie
 	cookie.SetExpire(time.Now().Add(-time.Hour))
 	cookie.SetHTTPOnly(true)
@@ -271,6 +306,9 @@ func (a *Provider) Register(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
 	if post == nil {
 		return false, map[string]error{"flash": errors.New("invalid request")}
 	}
+	if !a.verifyCsrf(ctx) {
+		return false, csrfErrors()
+	}
 
 	validationErrors := make(map[string]error)
 
@@ -317,6 +355,9 @@ func (a *Provider) ChangePassword(ctx *fasthttp.RequestCtx) (bool, map[string]er
 	if post == nil {
 		return false, map[string]error{"flash": errors.New("invalid request")}
 	}
+	if !a.verifyCsrf(ctx) {
+		return false, csrfErrors()
+	}
 
 	validationErrors := make(map[string]error)
 
@@ -377,3 +418,12 @@ func (a *Provider) ChangePassword(ctx *fasthttp.RequestCtx) (bool, map[string]er
 
 	return true, nil
 }
+
+// verifyCsrf lets safe methods and requests carrying the csrf token through, it always passes without Csrf
+func (a *Provider) verifyCsrf(ctx *fasthttp.RequestCtx) bool {
+	return a.csrf == nil || a.csrf.Verify(ctx)
+}
+
+func csrfErrors() map[string]error {
+	return map[string]error{"flash": errors.New("the form has expired, please reload the page and try again")}
+}
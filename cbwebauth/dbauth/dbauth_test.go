@@ -0,0 +1,145 @@
+package dbauth_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebauth/dbauth"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/crypto/bcrypt"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type user struct {
+	email    string
+	password string
+}
+
+func (u *user) GetEmail() string            { return u.email }
+func (u *user) SetEmail(email string)       { u.email = email }
+func (u *user) GetPassword() string         { return u.password }
+func (u *user) SetPassword(password string) { u.password = password }
+func (u *user) GetCreated() time.Time       { return time.Time{} }
+func (u *user) GetPermissions() []string    { return nil }
+
+type dbauthModule struct {
+	provider *dbauth.Provider
+	csrf     *cbweb.Csrf
+}
+
+func (m *dbauthModule) SetRoutes(r *router.Router) {
+	forms := map[string]func(ctx *fasthttp.RequestCtx) (bool, map[string]error){
+		"/login":           m.provider.Login,
+		"/register":        m.provider.Register,
+		"/change-password": m.provider.ChangePassword,
+	}
+	for path, form := range forms {
+		form := form
+		r.POST(path, func(ctx *fasthttp.RequestCtx) {
+			ok, validationErrors := form(ctx)
+			var messages []string
+			for field, e := range validationErrors {
+				messages = append(messages, field+": "+e.Error())
+			}
+			sort.Strings(messages)
+			if !ok {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			}
+			ctx.SetBodyString(strings.Join(messages, "\n"))
+		})
+	}
+	r.ANY("/logout", func(ctx *fasthttp.RequestCtx) {
+		if !m.provider.Logout(ctx) {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		}
+	})
+	// rendering a form is what gives the browser its csrf cookie
+	r.GET("/form", cbweb.MiddlewareHandler{}.
+		AddMiddleware(m.csrf.Middleware).
+		SetFinal(func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString(string(cbweb.NewMasterViewModel(ctx).CsrfField()))
+		}).
+		Handle,
+	)
+}
+
+func (m *dbauthModule) GetGlobalTemplates() map[string][]byte {
+	return nil
+}
+
+func (m *dbauthModule) SetGlobalTemplates(templates map[string][]byte) {}
+
+func TestCsrfOnForms(t *testing.T) {
+	hash, e := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if e != nil {
+		t.Fatal(e)
+	}
+	users := []dbauth.UserRecord{&user{email: "ada@example.com", password: string(hash)}}
+	csrf := cbweb.NewCsrf(cbweb.CsrfConfig{})
+	provider, e := dbauth.New(dbauth.Dependencies{
+		Secret:             "secret",
+		GetUserRecordsFunc: func() []dbauth.UserRecord { return users },
+		GenerateAuthHashFunc: func(user dbauth.UserRecord) string {
+			return user.GetPassword()
+		},
+		Csrf: csrf,
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	h := cbwebtest.New(t, cbweb.Dependencies{}, &dbauthModule{provider: provider, csrf: csrf})
+	h.Get("/form").AssertBodyContains(`name="csrf_token"`)
+	token := h.GetCookie("cbweb-csrf")
+
+	expired := "flash: the form has expired, please reload the page and try again"
+	tests := []struct {
+		path     string
+		values   url.Values
+		expected string
+	}{
+		{path: "/login", values: url.Values{"email": {"ada@example.com"}, "password": {"secret"}}},
+		// the rest stop at validation after the token is accepted, so no email host is looked up
+		{path: "/register", values: url.Values{"email": {"ada"}}, expected: "email: please provide a valid email"},
+		{path: "/change-password", values: url.Values{"email": {"ada"}}, expected: "email: please provide a valid email"},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.TrimPrefix(test.path, "/"), func(t *testing.T) {
+			h.PostForm(test.path, test.values).
+				AssertStatus(fasthttp.StatusBadRequest).
+				AssertBodyContains(expired)
+
+			bad := url.Values{"csrf_token": {strings.Repeat("0", 64)}}
+			for name, values := range test.values {
+				bad[name] = values
+			}
+			h.PostForm(test.path, bad).
+				AssertStatus(fasthttp.StatusBadRequest).
+				AssertBodyContains(expired)
+
+			good := url.Values{"csrf_token": {token}}
+			for name, values := range test.values {
+				good[name] = values
+			}
+			response := h.PostForm(test.path, good).AssertBodyNotContains(expired)
+			if test.expected != "" {
+				response.AssertBodyContains(test.expected)
+			} else {
+				response.AssertStatus(fasthttp.StatusOK)
+			}
+		})
+	}
+
+	if h.GetCookie("cbauth") == "" {
+		t.Error("expected the login with a token to set the auth cookie")
+	}
+
+	// a cross site post cannot log the user out, a link to the logout page still works
+	h.PostForm("/logout", url.Values{}).AssertStatus(fasthttp.StatusBadRequest)
+	h.PostForm("/logout", url.Values{"csrf_token": {token}}).AssertStatus(fasthttp.StatusOK)
+	h.Get("/logout").AssertStatus(fasthttp.StatusOK)
+}
@@ -0,0 +1,247 @@
+package cbwebredis
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Error is an error reply from the server, the connection it came from is still usable
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+type Config struct {
+	// Address defaults to 127.0.0.1:6379
+	Address  string
+	Password string
+	Database int
+	// DialTimeout defaults to 5 seconds
+	DialTimeout time.Duration
+	// Timeout is the deadline for each command, defaults to 5 seconds
+	Timeout time.Duration
+	// PoolSize is how many idle connections are kept, defaults to 10
+	PoolSize int
+}
+
+// Client is a small RESP client with a connection pool, it supports the commands cbweb needs rather than all of redis
+type Client struct {
+	config Config
+	pool   chan *Conn
+}
+
+type Conn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writer  *bufio.Writer
+	timeout time.Duration
+	broken  bool
+}
+
+func New(config Config) *Client {
+	if config.Address == "" {
+		config.Address = "127.0.0.1:6379"
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = time.Second * 5
+	}
+	if config.Timeout == 0 {
+		config.Timeout = time.Second * 5
+	}
+	if config.PoolSize == 0 {
+		config.PoolSize = 10
+	}
+
+	return &Client{
+		config: config,
+		pool:   make(chan *Conn, config.PoolSize),
+	}
+}
+
+// Do runs a single command on a pooled connection. Replies are returned as string, int64, nil,
+// []interface{} or an Error
+func (c *Client) Do(args ...string) (interface{}, error) {
+	var reply interface{}
+	e := c.WithConn(func(conn *Conn) error {
+		var e error
+		reply, e = conn.Do(args...)
+		return e
+	})
+
+	return reply, e
+}
+
+// WithConn gives fn a connection to itself, use it for WATCH and MULTI which only apply to one connection
+func (c *Client) WithConn(fn func(conn *Conn) error) error {
+	conn, e := c.get()
+	if e != nil {
+		return e
+	}
+	defer c.put(conn)
+
+	return fn(conn)
+}
+
+func (c *Client) Close() error {
+	for {
+		select {
+		case conn := <-c.pool:
+			_ = conn.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Client) get() (*Conn, error) {
+	select {
+	case conn := <-c.pool:
+		return conn, nil
+	default:
+	}
+
+	netConn, e := net.DialTimeout("tcp", c.config.Address, c.config.DialTimeout)
+	if e != nil {
+		return nil, e
+	}
+	conn := &Conn{
+		conn:    netConn,
+		reader:  bufio.NewReader(netConn),
+		writer:  bufio.NewWriter(netConn),
+		timeout: c.config.Timeout,
+	}
+
+	if c.config.Password != "" {
+		_, e = conn.Do("AUTH", c.config.Password)
+		if e != nil {
+			_ = netConn.Close()
+			return nil, e
+		}
+	}
+	if c.config.Database != 0 {
+		_, e = conn.Do("SELECT", strconv.Itoa(c.config.Database))
+		if e != nil {
+			_ = netConn.Close()
+			return nil, e
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *Client) put(conn *Conn) {
+	if conn.broken {
+		_ = conn.conn.Close()
+		return
+	}
+
+	select {
+	case c.pool <- conn:
+	default:
+		_ = conn.conn.Close()
+	}
+}
+
+func (c *Conn) Do(args ...string) (interface{}, error) {
+	if c.broken {
+		return nil, errors.New("redis connection is broken")
+	}
+
+	e := c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if e == nil {
+		e = c.write(args)
+	}
+	if e != nil {
+		c.broken = true
+		return nil, e
+	}
+
+	reply, e := c.read()
+	if _, ok := e.(Error); e != nil && !ok {
+		c.broken = true
+	}
+
+	return reply, e
+}
+
+func (c *Conn) write(args []string) error {
+	_, _ = c.writer.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		_, _ = c.writer.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+
+	return c.writer.Flush()
+}
+
+func (c *Conn) read() (interface{}, error) {
+	line, e := c.readLine()
+	if e != nil {
+		return nil, e
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis sent an empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, e := strconv.Atoi(line[1:])
+		if e != nil {
+			return nil, e
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		bulk := make([]byte, size+2)
+		_, e = io.ReadFull(c.reader, bulk)
+		if e != nil {
+			return nil, e
+		}
+		return string(bulk[:size]), nil
+	case '*':
+		size, e := strconv.Atoi(line[1:])
+		if e != nil {
+			return nil, e
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		replies := make([]interface{}, size)
+		for i := range replies {
+			replies[i], e = c.read()
+			// an error inside an EXEC reply belongs to one queued command, keep reading the rest
+			if _, ok := e.(Error); ok {
+				replies[i] = e
+				continue
+			}
+			if e != nil {
+				return nil, e
+			}
+		}
+		return replies, nil
+	}
+
+	return nil, errors.New("redis sent an unknown reply type " + string(line[0]))
+}
+
+func (c *Conn) readLine() (string, error) {
+	line, e := c.reader.ReadString('\n')
+	if e != nil {
+		return "", e
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.New("redis sent a malformed reply")
+	}
+
+	return line[:len(line)-2], nil
+}
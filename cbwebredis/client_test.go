@@ -0,0 +1,237 @@
+package cbwebredis_test
+
+import (
+	"bufio"
+	"github.com/codingbeard/cbweb/cbwebredis"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// scriptedServer answers PING and ERR, closes the connection on DROP and never answers HANG,
+// it counts the connections so tests can see what the pool did
+type scriptedServer struct {
+	address     string
+	connections int32
+}
+
+func newScriptedServer(t *testing.T) *scriptedServer {
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	s := &scriptedServer{address: listener.Addr().String()}
+	go func() {
+		for {
+			conn, e := listener.Accept()
+			if e != nil {
+				return
+			}
+			atomic.AddInt32(&s.connections, 1)
+			go s.handle(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *scriptedServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		// commands are arrays of bulk strings, only the name is needed
+		var name string
+		line, e := reader.ReadString('\n')
+		if e != nil {
+			return
+		}
+		count := 0
+		for _, c := range strings.TrimSpace(line[1:]) {
+			count = count*10 + int(c-'0')
+		}
+		for i := 0; i < count*2; i++ {
+			line, e = reader.ReadString('\n')
+			if e != nil {
+				return
+			}
+			if i == 1 {
+				name = strings.TrimSpace(line)
+			}
+		}
+
+		switch name {
+		case "PING":
+			_, _ = conn.Write([]byte("+PONG\r\n"))
+		case "ERR":
+			_, _ = conn.Write([]byte("-ERR scripted error\r\n"))
+		case "DROP":
+			return
+		case "HANG":
+			time.Sleep(time.Second)
+			return
+		}
+	}
+}
+
+func (s *scriptedServer) getConnections() int {
+	return int(atomic.LoadInt32(&s.connections))
+}
+
+func TestClientPool(t *testing.T) {
+	server := newScriptedServer(t)
+	client := cbwebredis.New(cbwebredis.Config{Address: server.address, Timeout: time.Millisecond * 100})
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		reply, e := client.Do("PING")
+		if e != nil || reply != "PONG" {
+			t.Fatalf("expected PONG, got %v %v", reply, e)
+		}
+	}
+	if server.getConnections() != 1 {
+		t.Fatalf("expected one pooled connection to be reused, %d were opened", server.getConnections())
+	}
+
+	// an error reply leaves the connection usable
+	_, e := client.Do("ERR")
+	if _, ok := e.(cbwebredis.Error); !ok {
+		t.Fatalf("expected an Error reply, got %v", e)
+	}
+	if _, e = client.Do("PING"); e != nil {
+		t.Fatal(e)
+	}
+	if server.getConnections() != 1 {
+		t.Fatalf("expected the connection to be kept after an error reply, %d were opened", server.getConnections())
+	}
+
+	// a dropped connection is not an Error and is never put back in the pool
+	_, e = client.Do("DROP")
+	if _, ok := e.(cbwebredis.Error); e == nil || ok {
+		t.Fatalf("expected a connection error, got %v", e)
+	}
+	if _, e = client.Do("PING"); e != nil {
+		t.Fatal(e)
+	}
+	if server.getConnections() != 2 {
+		t.Fatalf("expected a new connection after the drop, %d were opened", server.getConnections())
+	}
+
+	// so is one which timed out, its reply could arrive in the middle of the next command's
+	if _, e = client.Do("HANG"); e == nil {
+		t.Fatal("expected the command to time out")
+	}
+	if _, e = client.Do("PING"); e != nil {
+		t.Fatal(e)
+	}
+	if server.getConnections() != 3 {
+		t.Fatalf("expected a new connection after the timeout, %d were opened", server.getConnections())
+	}
+}
+
+func TestClientBrokenConn(t *testing.T) {
+	server := newScriptedServer(t)
+	client := cbwebredis.New(cbwebredis.Config{Address: server.address})
+	defer client.Close()
+
+	e := client.WithConn(func(conn *cbwebredis.Conn) error {
+		_, e := conn.Do("DROP")
+		if e == nil {
+			t.Error("expected a connection error")
+		}
+		_, e = conn.Do("PING")
+		return e
+	})
+	if e == nil {
+		t.Fatal("expected commands on a broken connection to fail")
+	}
+}
+
+func TestClientDialError(t *testing.T) {
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	client := cbwebredis.New(cbwebredis.Config{Address: address, DialTimeout: time.Millisecond * 100})
+	if _, e = client.Do("PING"); e == nil {
+		t.Fatal("expected an error when nothing is listening")
+	}
+}
+
+func TestClientWatchAbort(t *testing.T) {
+	redis := cbwebtest.NewRedisServer(t)
+	client := cbwebredis.New(cbwebredis.Config{Address: redis.Address})
+	defer client.Close()
+
+	e := client.WithConn(func(conn *cbwebredis.Conn) error {
+		if _, e := conn.Do("WATCH", "key"); e != nil {
+			return e
+		}
+		// another connection changes the watched key
+		if _, e := client.Do("SET", "key", "theirs"); e != nil {
+			return e
+		}
+		for _, command := range [][]string{{"MULTI"}, {"SET", "key", "ours"}} {
+			if _, e := conn.Do(command...); e != nil {
+				return e
+			}
+		}
+
+		reply, e := conn.Do("EXEC")
+		if e != nil {
+			return e
+		}
+		if reply != nil {
+			t.Errorf("expected EXEC to be aborted with a nil reply, got %v", reply)
+		}
+
+		return nil
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	value, e := client.Do("GET", "key")
+	if e != nil || value != "theirs" {
+		t.Fatalf("expected the other connection's value to be kept, got %v %v", value, e)
+	}
+
+	// an EXEC which was not aborted returns every queued command's reply, errors included
+	e = client.WithConn(func(conn *cbwebredis.Conn) error {
+		for _, command := range [][]string{{"MULTI"}, {"INCR", "key"}, {"SET", "key", "ours"}} {
+			if _, e := conn.Do(command...); e != nil {
+				return e
+			}
+		}
+
+		reply, e := conn.Do("EXEC")
+		if e != nil {
+			return e
+		}
+		replies, ok := reply.([]interface{})
+		if !ok || len(replies) != 2 {
+			t.Fatalf("expected two replies, got %v", reply)
+		}
+		if _, ok := replies[0].(cbwebredis.Error); !ok {
+			t.Errorf("expected INCR of a string to be an Error, got %v", replies[0])
+		}
+		if replies[1] != "OK" {
+			t.Errorf("expected SET to reply OK, got %v", replies[1])
+		}
+
+		return nil
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+}
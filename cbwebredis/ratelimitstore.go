@@ -0,0 +1,138 @@
+package cbwebredis
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// RateLimitStore is a cbweb.RateLimitStore which lets every replica share its limits through redis,
+// it only uses MULTI and WATCH so it works with any redis compatible server without scripting
+type RateLimitStore struct {
+	client *Client
+}
+
+func NewRateLimitStore(client *Client) *RateLimitStore {
+	return &RateLimitStore{client: client}
+}
+
+func (s *RateLimitStore) Increment(key string, ttl time.Duration) (int64, error) {
+	var count int64
+	e := s.client.WithConn(func(conn *Conn) error {
+		replies, e := transaction(conn, [][]string{
+			{"SET", key, "0", "PX", formatMilliseconds(ttl), "NX"},
+			{"INCR", key},
+		})
+		if e != nil {
+			return e
+		}
+		if replies == nil || len(replies) != 2 {
+			return errors.New("redis transaction for " + key + " was aborted")
+		}
+		if e, ok := replies[1].(error); ok {
+			return e
+		}
+		var ok bool
+		count, ok = replies[1].(int64)
+		if !ok {
+			return errors.New("redis INCR " + key + " did not return an integer")
+		}
+		return nil
+	})
+
+	return count, e
+}
+
+func (s *RateLimitStore) Get(key string) (string, error) {
+	reply, e := s.client.Do("GET", key)
+	if e != nil || reply == nil {
+		return "", e
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return "", errors.New("redis GET " + key + " did not return a string")
+	}
+
+	return value, nil
+}
+
+func (s *RateLimitStore) CompareAndSwap(key, old, value string, ttl time.Duration) (bool, error) {
+	swapped := false
+	e := s.client.WithConn(func(conn *Conn) error {
+		_, e := conn.Do("WATCH", key)
+		if e != nil {
+			return e
+		}
+
+		reply, e := conn.Do("GET", key)
+		if e != nil {
+			return unwatch(conn, e)
+		}
+		current, _ := reply.(string)
+		if current != old {
+			return unwatch(conn, nil)
+		}
+
+		replies, e := transaction(conn, [][]string{{"SET", key, value, "PX", formatMilliseconds(ttl)}})
+		if e != nil {
+			return unwatch(conn, e)
+		}
+		// a nil EXEC reply means the watched key changed
+		swapped = replies != nil
+
+		return nil
+	})
+
+	return swapped, e
+}
+
+// unwatch stops the connection watching before it goes back to the pool, a connection which cannot is closed
+func unwatch(conn *Conn, e error) error {
+	_, unwatchError := conn.Do("UNWATCH")
+	if unwatchError != nil {
+		conn.broken = true
+		if e == nil {
+			return unwatchError
+		}
+	}
+
+	return e
+}
+
+// transaction runs the commands in MULTI and EXEC, the replies are nil when a watched key changed
+func transaction(conn *Conn, commands [][]string) ([]interface{}, error) {
+	_, e := conn.Do("MULTI")
+	if e != nil {
+		return nil, e
+	}
+	for _, command := range commands {
+		_, e = conn.Do(command...)
+		if e != nil {
+			_, discardError := conn.Do("DISCARD")
+			if discardError != nil {
+				conn.broken = true
+			}
+			return nil, e
+		}
+	}
+
+	reply, e := conn.Do("EXEC")
+	if e != nil || reply == nil {
+		return nil, e
+	}
+	replies, ok := reply.([]interface{})
+	if !ok {
+		return nil, errors.New("redis EXEC did not return an array")
+	}
+
+	return replies, nil
+}
+
+func formatMilliseconds(duration time.Duration) string {
+	milliseconds := int64(duration / time.Millisecond)
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+
+	return strconv.FormatInt(milliseconds, 10)
+}
@@ -0,0 +1,171 @@
+package cbwebtest
+
+import (
+	"errors"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/valyala/fasthttp"
+	"strings"
+	"sync"
+)
+
+var (
+	FakeProviderName = "cbwebtest"
+	FakeAuthCookie   = "cbwebtest-auth"
+)
+
+type FakeUser struct {
+	Identifier  string
+	Password    string
+	Permissions []string
+}
+
+// FakeAuthProvider is a cbwebauth.Provider that trusts the identifier stored in the FakeAuthCookie,
+// Login and Register read identifier and password post args
+type FakeAuthProvider struct {
+	Name  string
+	users map[string]FakeUser
+	lock  sync.RWMutex
+}
+
+func NewFakeAuthProvider(users ...FakeUser) *FakeAuthProvider {
+	provider := &FakeAuthProvider{
+		Name:  FakeProviderName,
+		users: make(map[string]FakeUser),
+	}
+	for _, user := range users {
+		provider.AddUser(user)
+	}
+
+	return provider
+}
+
+func (p *FakeAuthProvider) AddUser(user FakeUser) {
+	p.lock.Lock()
+	p.users[strings.ToLower(user.Identifier)] = user
+	p.lock.Unlock()
+}
+
+func (p *FakeAuthProvider) GetUser(identifier string) (FakeUser, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	user, ok := p.users[strings.ToLower(identifier)]
+
+	return user, ok
+}
+
+func (p *FakeAuthProvider) getCurrentUser(ctx *fasthttp.RequestCtx) (FakeUser, bool) {
+	identifier := ctx.Request.Header.Cookie(FakeAuthCookie)
+	if len(identifier) == 0 {
+		return FakeUser{}, false
+	}
+
+	return p.GetUser(string(identifier))
+}
+
+func (p *FakeAuthProvider) GetProviderName() string {
+	return p.Name
+}
+
+func (p *FakeAuthProvider) GetUniqueIdentifier(ctx *fasthttp.RequestCtx) string {
+	user, ok := p.getCurrentUser(ctx)
+	if !ok {
+		return ""
+	}
+
+	return user.Identifier
+}
+
+func (p *FakeAuthProvider) GetPermissions(ctx *fasthttp.RequestCtx) []string {
+	user, ok := p.getCurrentUser(ctx)
+	if !ok {
+		return []string{}
+	}
+
+	return append(append([]string{}, user.Permissions...), cbwebauth.LoggedIn)
+}
+
+func (p *FakeAuthProvider) IsAuthenticated(ctx *fasthttp.RequestCtx) bool {
+	_, ok := p.getCurrentUser(ctx)
+
+	return ok
+}
+
+func (p *FakeAuthProvider) Login(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
+	post := ctx.Request.PostArgs()
+	if post == nil || post.Len() == 0 {
+		return false, map[string]error{"flash": errors.New("invalid request")}
+	}
+
+	user, ok := p.GetUser(string(post.Peek("identifier")))
+	if !ok {
+		return false, map[string]error{"identifier": errors.New("user not found")}
+	}
+	if user.Password != string(post.Peek("password")) {
+		return false, map[string]error{"password": errors.New("invalid password")}
+	}
+
+	p.setCookie(ctx, user.Identifier)
+
+	return true, make(map[string]error)
+}
+
+func (p *FakeAuthProvider) Logout(ctx *fasthttp.RequestCtx) bool {
+	p.setCookie(ctx, "")
+
+	return true
+}
+
+func (p *FakeAuthProvider) Register(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
+	post := ctx.Request.PostArgs()
+	if post == nil || !post.Has("identifier") {
+		return false, map[string]error{"identifier": errors.New("please provide an identifier")}
+	}
+	if _, ok := p.GetUser(string(post.Peek("identifier"))); ok {
+		return false, map[string]error{"identifier": errors.New("that user already exists")}
+	}
+
+	p.AddUser(FakeUser{
+		Identifier: string(post.Peek("identifier")),
+		Password:   string(post.Peek("password")),
+	})
+
+	return true, make(map[string]error)
+}
+
+func (p *FakeAuthProvider) ChangePassword(ctx *fasthttp.RequestCtx) (bool, map[string]error) {
+	post := ctx.Request.PostArgs()
+	if post == nil {
+		return false, map[string]error{"flash": errors.New("invalid request")}
+	}
+
+	user, ok := p.GetUser(string(post.Peek("identifier")))
+	if !ok {
+		return false, map[string]error{"identifier": errors.New("that user does not exist")}
+	}
+
+	user.Password = string(post.Peek("password"))
+	p.AddUser(user)
+
+	return true, make(map[string]error)
+}
+
+func (p *FakeAuthProvider) setCookie(ctx *fasthttp.RequestCtx, identifier string) {
+	var cookie fasthttp.Cookie
+	cookie.SetKey(FakeAuthCookie)
+	cookie.SetValue(identifier)
+	cookie.SetPath("/")
+	if identifier == "" {
+		cookie.SetExpire(fasthttp.CookieExpireDelete)
+	}
+	ctx.Response.Header.SetCookie(&cookie)
+}
+
+// LoginAs makes the harness send requests as the user with the identifier when using a FakeAuthProvider
+func (h *Harness) LoginAs(identifier string) {
+	h.SetCookie(FakeAuthCookie, identifier)
+}
+
+func (h *Harness) Logout() {
+	h.DeleteCookie(FakeAuthCookie)
+}
@@ -0,0 +1,89 @@
+package cbwebtest_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"net/url"
+	"testing"
+)
+
+type authModule struct {
+	auth *cbwebauth.Container
+	acl  *cbwebauth.Acl
+}
+
+func (m *authModule) SetRoutes(r *router.Router) {
+	r.POST("/login", func(ctx *fasthttp.RequestCtx) {
+		ok, _, validationErrors := m.auth.Login(cbwebtest.FakeProviderName, ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			for field, e := range validationErrors {
+				ctx.WriteString(field + ": " + e.Error())
+			}
+			return
+		}
+		ctx.Redirect("/admin", fasthttp.StatusSeeOther)
+	})
+	r.GET("/logout", func(ctx *fasthttp.RequestCtx) {
+		_, _ = m.auth.Logout(cbwebtest.FakeProviderName, ctx, "/admin")
+	})
+	r.GET("/admin", cbweb.MiddlewareHandler{}.
+		AddMiddleware(m.acl.Middleware([]string{"admin"}, "/denied")).
+		SetFinal(func(ctx *fasthttp.RequestCtx) {
+			identifier, _ := m.auth.GetUniqueIdentifier(cbwebtest.FakeProviderName, ctx)
+			ctx.SetBodyString("welcome " + identifier)
+		}).
+		Handle,
+	)
+	r.GET("/denied", func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetBodyString("denied")
+	})
+}
+
+func (m *authModule) GetGlobalTemplates() map[string][]byte {
+	return nil
+}
+
+func (m *authModule) SetGlobalTemplates(templates map[string][]byte) {}
+
+func TestFakeAuthProvider(t *testing.T) {
+	provider := cbwebtest.NewFakeAuthProvider(
+		cbwebtest.FakeUser{Identifier: "ada", Password: "secret", Permissions: []string{"admin"}},
+		cbwebtest.FakeUser{Identifier: "grace", Password: "secret"},
+	)
+	auth := cbwebauth.New(cbwebauth.Config{Providers: []cbwebauth.Provider{provider}})
+	h := cbwebtest.New(t, cbweb.Dependencies{}, &authModule{auth: auth, acl: &cbwebauth.Acl{Auth: auth}})
+
+	h.Get("/admin").AssertStatus(fasthttp.StatusForbidden).AssertRedirectedTo("/denied")
+
+	h.PostForm("/login", url.Values{"identifier": {"ada"}, "password": {"wrong"}}).
+		AssertStatus(fasthttp.StatusUnauthorized).
+		AssertBodyContains("password: invalid password")
+	h.PostForm("/login", url.Values{"identifier": {"nobody"}, "password": {"secret"}}).
+		AssertStatus(fasthttp.StatusUnauthorized).
+		AssertBodyContains("identifier: user not found")
+
+	h.PostForm("/login", url.Values{"identifier": {"ada"}, "password": {"secret"}}).
+		AssertStatus(fasthttp.StatusOK).
+		AssertBodyContains("welcome ada")
+	if h.GetCookie(cbwebtest.FakeAuthCookie) != "ada" {
+		t.Errorf("expected login to set the auth cookie, got %q", h.GetCookie(cbwebtest.FakeAuthCookie))
+	}
+
+	h.Get("/logout").AssertStatus(fasthttp.StatusForbidden).AssertRedirectedTo("/denied")
+	if h.GetCookie(cbwebtest.FakeAuthCookie) != "" {
+		t.Errorf("expected logout to clear the auth cookie, got %q", h.GetCookie(cbwebtest.FakeAuthCookie))
+	}
+
+	// LoginAs skips the form, permissions still come from the user
+	h.LoginAs("grace")
+	h.Get("/admin").AssertStatus(fasthttp.StatusForbidden)
+	h.LoginAs("ada")
+	h.Get("/admin").AssertStatus(fasthttp.StatusOK).AssertBodyContains("welcome ada")
+	h.Logout()
+	h.Get("/admin").AssertStatus(fasthttp.StatusForbidden)
+}
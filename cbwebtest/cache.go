@@ -0,0 +1,76 @@
+package cbwebtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type cacheItem struct {
+	value   interface{}
+	expires time.Time
+}
+
+// Cache is an in-memory cbweb.CacheProvider that records what it holds so tests can inspect it
+type Cache struct {
+	items map[string]cacheItem
+	lock  sync.Mutex
+}
+
+func NewCache() *Cache {
+	return &Cache{items: make(map[string]cacheItem)}
+}
+
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !item.expires.IsZero() && item.expires.Before(time.Now()) {
+		delete(c.items, key)
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+func (c *Cache) Delete(key string) {
+	c.lock.Lock()
+	delete(c.items, key)
+	c.lock.Unlock()
+}
+
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	item := cacheItem{value: value}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	c.lock.Lock()
+	c.items[key] = item
+	c.lock.Unlock()
+}
+
+func (c *Cache) Keys() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var keys []string
+	for key, item := range c.items {
+		if item.expires.IsZero() || item.expires.After(time.Now()) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (c *Cache) Clear() {
+	c.lock.Lock()
+	c.items = make(map[string]cacheItem)
+	c.lock.Unlock()
+}
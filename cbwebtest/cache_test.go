@@ -0,0 +1,37 @@
+package cbwebtest_test
+
+import (
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cache := cbwebtest.NewCache()
+
+	cache.Set("b", 2, 0)
+	cache.Set("a", "one", time.Hour)
+	cache.Set("expired", true, time.Millisecond)
+	time.Sleep(time.Millisecond * 5)
+
+	if value, ok := cache.Get("a"); !ok || value != "one" {
+		t.Errorf("expected a to be one, got %v %v", value, ok)
+	}
+	if _, ok := cache.Get("expired"); ok {
+		t.Error("expected the expired item to be gone")
+	}
+	if keys := cache.Keys(); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("expected the keys a and b, got %v", keys)
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected a to be deleted")
+	}
+
+	cache.Clear()
+	if keys := cache.Keys(); len(keys) != 0 {
+		t.Errorf("expected an empty cache, got %v", keys)
+	}
+}
@@ -0,0 +1,283 @@
+package cbwebtest
+
+import (
+	"context"
+	"github.com/codingbeard/cbweb"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	ListenerName   = "cbwebtest"
+	DefaultHost    = "cbwebtest.local"
+	StartTimeout   = time.Second * 5
+	DefaultHeaders = map[string]string{"User-Agent": "cbwebtest"}
+)
+
+type Harness struct {
+	Server          *cbweb.Server
+	Host            string
+	FollowRedirects bool
+	MaxRedirects    int
+	t               testing.TB
+	listener        *fasthttputil.InmemoryListener
+	client          *fasthttp.HostClient
+	cookies         map[string]*fasthttp.Cookie
+	cookiesLock     sync.Mutex
+}
+
+// New builds a server from the dependencies and modules and starts it on an in-memory listener,
+// any Port or Listeners in the dependencies are ignored so no real port is bound
+func New(t testing.TB, dependencies cbweb.Dependencies, modules ...cbweb.Module) *Harness {
+	dependencies.Port = ""
+	dependencies.Listeners = nil
+
+	return Start(t, cbweb.NewServer(dependencies, modules...))
+}
+
+// Start adds an in-memory listener to a server built with no Port and starts it,
+// the server is shut down when the test finishes
+func Start(t testing.TB, server *cbweb.Server) *Harness {
+	t.Helper()
+
+	listener := fasthttputil.NewInmemoryListener()
+	server.AddListener(cbweb.Listener{
+		Name:        ListenerName,
+		NetListener: listener,
+	})
+
+	h := &Harness{
+		Server:          server,
+		Host:            DefaultHost,
+		FollowRedirects: true,
+		MaxRedirects:    10,
+		t:               t,
+		listener:        listener,
+		cookies:         make(map[string]*fasthttp.Cookie),
+	}
+	h.client = &fasthttp.HostClient{
+		Addr: DefaultHost,
+		Dial: func(addr string) (net.Conn, error) {
+			return listener.Dial()
+		},
+	}
+
+	started := make(chan error, 1)
+	go func() {
+		started <- server.Start()
+	}()
+
+	deadline := time.Now().Add(StartTimeout)
+	for !server.IsReady() {
+		select {
+		case e := <-started:
+			if e == nil {
+				t.Fatal("cbwebtest: server stopped before it was ready")
+			}
+			t.Fatal("cbwebtest: starting server: " + e.Error())
+		case <-time.After(time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cbwebtest: server was not ready after " + StartTimeout.String())
+		}
+	}
+
+	t.Cleanup(h.Close)
+
+	return h
+}
+
+func (h *Harness) Close() {
+	e := h.Server.Shutdown(context.Background())
+	if e != nil {
+		h.t.Error("cbwebtest: shutting down server: " + e.Error())
+	}
+}
+
+func (h *Harness) Get(path string) *Response {
+	h.t.Helper()
+
+	return h.Do(fasthttp.MethodGet, path, nil, nil)
+}
+
+func (h *Harness) PostForm(path string, values url.Values) *Response {
+	h.t.Helper()
+
+	return h.Do(fasthttp.MethodPost, path, []byte(values.Encode()), map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	})
+}
+
+func (h *Harness) PostJson(path string, json []byte) *Response {
+	h.t.Helper()
+
+	return h.Do(fasthttp.MethodPost, path, json, map[string]string{
+		"Content-Type": "application/json",
+	})
+}
+
+func (h *Harness) Do(method, path string, body []byte, headers map[string]string) *Response {
+	h.t.Helper()
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	req.Header.SetMethod(method)
+	req.SetRequestURI(path)
+	for name, value := range DefaultHeaders {
+		req.Header.Set(name, value)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if body != nil {
+		req.SetBody(body)
+	}
+
+	return h.DoRequest(req)
+}
+
+// DoRequest sends the request with the cookie jar, following redirects when FollowRedirects is set
+func (h *Harness) DoRequest(req *fasthttp.Request) *Response {
+	h.t.Helper()
+
+	var redirects []string
+	current := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(current)
+	req.CopyTo(current)
+
+	for {
+		if len(current.Header.Host()) == 0 {
+			current.Header.SetHost(h.Host)
+		}
+		current.URI().SetHost(string(current.Header.Host()))
+		h.addCookies(current)
+
+		resp := fasthttp.AcquireResponse()
+		e := h.client.Do(current, resp)
+		if e != nil {
+			fasthttp.ReleaseResponse(resp)
+			h.t.Fatal("cbwebtest: " + string(current.Header.Method()) + " " + string(current.RequestURI()) + ": " + e.Error())
+			return nil
+		}
+		h.storeCookies(resp)
+
+		location := string(resp.Header.Peek("Location"))
+		if !h.FollowRedirects || !isRedirect(resp.StatusCode()) || location == "" {
+			response := newResponse(h.t, current, resp, redirects)
+			fasthttp.ReleaseResponse(resp)
+			return response
+		}
+
+		if len(redirects) >= h.MaxRedirects {
+			fasthttp.ReleaseResponse(resp)
+			h.t.Fatal("cbwebtest: stopped after " + strings.Join(redirects, " -> "))
+			return nil
+		}
+
+		status := resp.StatusCode()
+		fasthttp.ReleaseResponse(resp)
+
+		next := current.URI().String()
+		base, e := url.Parse(next)
+		if e == nil {
+			if target, e := base.Parse(location); e == nil {
+				next = target.String()
+				// redirects on the harness host are recorded the way a module would write them, as a path
+				if target.Host == string(current.Header.Host()) {
+					location = target.RequestURI()
+				}
+			}
+		}
+		redirects = append(redirects, location)
+		// the next hop gets its cookies from the jar, which may have dropped some of them
+		current.Header.DelAllCookies()
+		current.SetRequestURI(next)
+		current.Header.SetHostBytes(current.URI().Host())
+		if status != fasthttp.StatusTemporaryRedirect && status != fasthttp.StatusPermanentRedirect {
+			current.Header.SetMethod(fasthttp.MethodGet)
+			current.ResetBody()
+			current.Header.SetContentLength(0)
+			current.Header.Del("Content-Type")
+		}
+	}
+}
+
+func isRedirect(status int) bool {
+	return status == fasthttp.StatusMovedPermanently ||
+		status == fasthttp.StatusFound ||
+		status == fasthttp.StatusSeeOther ||
+		status == fasthttp.StatusTemporaryRedirect ||
+		status == fasthttp.StatusPermanentRedirect
+}
+
+func (h *Harness) SetCookie(name, value string) {
+	cookie := fasthttp.AcquireCookie()
+	cookie.SetKey(name)
+	cookie.SetValue(value)
+
+	h.cookiesLock.Lock()
+	h.cookies[name] = cookie
+	h.cookiesLock.Unlock()
+}
+
+func (h *Harness) GetCookie(name string) string {
+	h.cookiesLock.Lock()
+	defer h.cookiesLock.Unlock()
+
+	if cookie, ok := h.cookies[name]; ok {
+		return string(cookie.Value())
+	}
+
+	return ""
+}
+
+func (h *Harness) DeleteCookie(name string) {
+	h.cookiesLock.Lock()
+	delete(h.cookies, name)
+	h.cookiesLock.Unlock()
+}
+
+func (h *Harness) ClearCookies() {
+	h.cookiesLock.Lock()
+	h.cookies = make(map[string]*fasthttp.Cookie)
+	h.cookiesLock.Unlock()
+}
+
+func (h *Harness) addCookies(req *fasthttp.Request) {
+	h.cookiesLock.Lock()
+	defer h.cookiesLock.Unlock()
+
+	for name, cookie := range h.cookies {
+		if !cookie.Expire().IsZero() && cookie.Expire() != fasthttp.CookieExpireUnlimited && cookie.Expire().Before(time.Now()) {
+			delete(h.cookies, name)
+			continue
+		}
+		req.Header.SetCookie(name, string(cookie.Value()))
+	}
+}
+
+func (h *Harness) storeCookies(resp *fasthttp.Response) {
+	h.cookiesLock.Lock()
+	defer h.cookiesLock.Unlock()
+
+	resp.Header.VisitAllCookie(func(key, value []byte) {
+		cookie := fasthttp.AcquireCookie()
+		if cookie.ParseBytes(value) != nil {
+			return
+		}
+		expired := cookie.MaxAge() < 0 ||
+			(!cookie.Expire().IsZero() && cookie.Expire() != fasthttp.CookieExpireUnlimited && cookie.Expire().Before(time.Now()))
+		if expired || len(cookie.Value()) == 0 {
+			delete(h.cookies, string(key))
+			return
+		}
+		h.cookies[string(key)] = cookie
+	})
+}
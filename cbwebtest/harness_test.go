@@ -0,0 +1,156 @@
+package cbwebtest_test
+
+import (
+	"bytes"
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/codingbeard/cbweb/module/cbwebcommon"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"html/template"
+	"net/url"
+	"testing"
+)
+
+type harnessModule struct {
+	flashTemplates *template.Template
+}
+
+type flashViewModel struct {
+	master cbweb.DefaultMasterViewModel
+}
+
+func (v flashViewModel) GetMasterViewModel() cbweb.DefaultMasterViewModel {
+	return v.master
+}
+
+func (m *harnessModule) SetRoutes(r *router.Router) {
+	r.POST("/login", func(ctx *fasthttp.RequestCtx) {
+		var cookie fasthttp.Cookie
+		cookie.SetKey("session")
+		cookie.SetValue(string(ctx.PostArgs().Peek("name")))
+		cookie.SetPath("/")
+		ctx.Response.Header.SetCookie(&cookie)
+		ctx.Redirect("/home", fasthttp.StatusSeeOther)
+	})
+	r.POST("/keep-method", func(ctx *fasthttp.RequestCtx) {
+		ctx.Redirect("/method", fasthttp.StatusTemporaryRedirect)
+	})
+	r.ANY("/method", func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBodyString(string(ctx.Method()) + " " + string(ctx.PostBody()))
+	})
+	r.GET("/home", func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBodyString("hello " + string(ctx.Request.Header.Cookie("session")))
+	})
+	r.GET("/logout", func(ctx *fasthttp.RequestCtx) {
+		var cookie fasthttp.Cookie
+		cookie.SetKey("session")
+		cookie.SetPath("/")
+		cookie.SetExpire(fasthttp.CookieExpireDelete)
+		ctx.Response.Header.SetCookie(&cookie)
+		ctx.Redirect("/home", fasthttp.StatusFound)
+	})
+	r.GET("/chain/{step}", func(ctx *fasthttp.RequestCtx) {
+		switch ctx.UserValue("step") {
+		case "1":
+			ctx.Redirect("/chain/2", fasthttp.StatusFound)
+		case "2":
+			ctx.Redirect("/chain/3", fasthttp.StatusMovedPermanently)
+		default:
+			ctx.SetBodyString("end of the chain")
+		}
+	})
+	r.GET("/flash", func(ctx *fasthttp.RequestCtx) {
+		flash := &cbweb.Flash{}
+		flash.AddMessage("default", cbweb.FlashMessage{Type: "green", Message: "Saved <b>it</b>"})
+		flash.AddMessage("toast", cbweb.FlashMessage{Type: "red", Message: `It's "quoted"`})
+		var body bytes.Buffer
+		viewModel := flashViewModel{master: cbweb.DefaultMasterViewModel{Flash: flash, CspNonce: "nonce"}}
+		for _, name := range []string{"-global-/cbwebcommon/flash.gohtml", "-global-/cbwebcommon/flashtoast.gohtml"} {
+			if e := m.flashTemplates.ExecuteTemplate(&body, name, viewModel); e != nil {
+				ctx.Error(e.Error(), fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+		ctx.SetContentType("text/html")
+		ctx.SetBody(body.Bytes())
+	})
+}
+
+func (m *harnessModule) GetGlobalTemplates() map[string][]byte {
+	return nil
+}
+
+func (m *harnessModule) SetGlobalTemplates(templates map[string][]byte) {}
+
+func newHarness(t *testing.T) *cbwebtest.Harness {
+	// the flash is rendered with cbwebcommon's own templates, which AssertFlash has to match
+	flashTemplates := template.New("flash")
+	globals := (&cbwebcommon.Module{}).GetGlobalTemplates()
+	for _, name := range []string{"-global-/cbwebcommon/flash.gohtml", "-global-/cbwebcommon/flashtoast.gohtml"} {
+		template.Must(flashTemplates.New(name).Parse(string(globals[name])))
+	}
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, &harnessModule{flashTemplates: flashTemplates})
+}
+
+func TestFollowRedirects(t *testing.T) {
+	h := newHarness(t)
+
+	response := h.Get("/chain/1").
+		AssertStatus(fasthttp.StatusOK).
+		AssertBodyContains("end of the chain").
+		AssertRedirectedTo("/chain/3")
+	if len(response.Redirects) != 2 || response.Redirects[0] != "/chain/2" {
+		t.Errorf("expected the redirects /chain/2 and /chain/3, got %v", response.Redirects)
+	}
+	if response.Uri != "/chain/3" {
+		t.Errorf("expected the response to be for /chain/3, got %s", response.Uri)
+	}
+
+	// a 303 changes the method to GET and drops the body, a 307 keeps both
+	h.PostForm("/login", url.Values{"name": {"ada"}}).
+		AssertStatus(fasthttp.StatusOK).
+		AssertRedirectedTo("/home")
+	h.PostForm("/keep-method", url.Values{"name": {"ada"}}).
+		AssertBodyContains("POST name=ada")
+
+	h.FollowRedirects = false
+	h.Get("/chain/1").
+		AssertStatus(fasthttp.StatusFound).
+		AssertRedirect("/chain/2")
+}
+
+func TestCookieJar(t *testing.T) {
+	h := newHarness(t)
+
+	h.PostForm("/login", url.Values{"name": {"ada"}}).AssertBodyContains("hello ada")
+	if h.GetCookie("session") != "ada" {
+		t.Errorf("expected the jar to hold the session cookie, got %q", h.GetCookie("session"))
+	}
+	h.Get("/home").AssertBodyContains("hello ada")
+
+	h.SetCookie("session", "grace")
+	h.Get("/home").AssertBodyContains("hello grace")
+
+	// an expired cookie is removed from the jar
+	h.Get("/logout").AssertBodyNotContains("grace")
+	if h.GetCookie("session") != "" {
+		t.Errorf("expected logout to remove the cookie, got %q", h.GetCookie("session"))
+	}
+
+	h.SetCookie("session", "ada")
+	h.ClearCookies()
+	h.Get("/home").AssertBodyNotContains("ada")
+}
+
+func TestAssertFlash(t *testing.T) {
+	h := newHarness(t)
+
+	h.Get("/flash").
+		AssertStatus(fasthttp.StatusOK).
+		AssertFlash(cbweb.FlashMessage{Type: "green", Message: "Saved <b>it</b>"}).
+		AssertFlash(cbweb.FlashMessage{Type: "red", Message: `It's "quoted"`}).
+		AssertNoFlash(cbweb.FlashMessage{Type: "red", Message: "Saved <b>it</b>"}).
+		AssertNoFlash(cbweb.FlashMessage{Type: "green", Message: "Deleted"})
+}
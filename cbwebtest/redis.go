@@ -0,0 +1,389 @@
+package cbwebtest
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type redisValue struct {
+	value   string
+	expires time.Time
+}
+
+// RedisServer is an in-memory stand-in for redis on a local port. It speaks RESP and supports the
+// commands cbwebredis uses: PING, AUTH, SELECT, GET, SET with PX and NX, INCR, DEL, PEXPIRE, PTTL,
+// WATCH, UNWATCH, MULTI, EXEC and DISCARD
+type RedisServer struct {
+	Address  string
+	listener net.Listener
+	values   map[string]redisValue
+	versions map[string]uint64
+	lock     sync.Mutex
+}
+
+type redisSession struct {
+	watched map[string]uint64
+	queued  [][]string
+	inMulti bool
+}
+
+// NewRedisServer starts a RedisServer on a free local port, it is closed when the test finishes
+func NewRedisServer(t testing.TB) *RedisServer {
+	t.Helper()
+
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal("cbwebtest: starting redis stand-in: " + e.Error())
+	}
+
+	s := &RedisServer{
+		Address:  listener.Addr().String(),
+		listener: listener,
+		values:   make(map[string]redisValue),
+		versions: make(map[string]uint64),
+	}
+	go s.serve()
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *RedisServer) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *RedisServer) Keys() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var keys []string
+	for key := range s.values {
+		if _, ok := s.get(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (s *RedisServer) FlushAll() {
+	s.lock.Lock()
+	for key := range s.values {
+		s.del(key)
+	}
+	s.lock.Unlock()
+}
+
+func (s *RedisServer) serve() {
+	for {
+		conn, e := s.listener.Accept()
+		if e != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *RedisServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	session := &redisSession{}
+
+	for {
+		command, e := readRedisCommand(reader)
+		if e != nil {
+			return
+		}
+
+		writeRedisReply(writer, s.run(session, command))
+		if writer.Flush() != nil {
+			return
+		}
+	}
+}
+
+func (s *RedisServer) run(session *redisSession, command []string) interface{} {
+	if len(command) == 0 {
+		return errors.New("ERR empty command")
+	}
+	name := strings.ToUpper(command[0])
+
+	if session.inMulti {
+		switch name {
+		case "EXEC":
+			session.inMulti = false
+			queued := session.queued
+			session.queued = nil
+			return s.exec(session, queued)
+		case "DISCARD":
+			session.inMulti = false
+			session.queued = nil
+			session.watched = nil
+			return "OK"
+		case "MULTI", "WATCH":
+			return errors.New("ERR " + name + " inside MULTI is not allowed")
+		}
+		session.queued = append(session.queued, command)
+		return "QUEUED"
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch name {
+	case "MULTI":
+		session.inMulti = true
+		return "OK"
+	case "EXEC", "DISCARD":
+		return errors.New("ERR " + name + " without MULTI")
+	case "WATCH":
+		if session.watched == nil {
+			session.watched = make(map[string]uint64)
+		}
+		for _, key := range command[1:] {
+			s.get(key)
+			session.watched[key] = s.versions[key]
+		}
+		return "OK"
+	case "UNWATCH":
+		session.watched = nil
+		return "OK"
+	}
+
+	return s.execute(command)
+}
+
+func (s *RedisServer) exec(session *redisSession, queued [][]string) interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	watched := session.watched
+	session.watched = nil
+	for key, version := range watched {
+		s.get(key)
+		if s.versions[key] != version {
+			return []interface{}(nil)
+		}
+	}
+
+	replies := make([]interface{}, len(queued))
+	for i, command := range queued {
+		replies[i] = s.execute(command)
+	}
+
+	return replies
+}
+
+func (s *RedisServer) execute(command []string) interface{} {
+	name := strings.ToUpper(command[0])
+	args := command[1:]
+
+	switch name {
+	case "PING":
+		return "PONG"
+	case "AUTH", "SELECT":
+		return "OK"
+	case "GET":
+		if len(args) != 1 {
+			return redisArgumentsError(name)
+		}
+		value, ok := s.get(args[0])
+		if !ok {
+			return nil
+		}
+		return []byte(value.value)
+	case "SET":
+		if len(args) < 2 {
+			return redisArgumentsError(name)
+		}
+		value := redisValue{value: args[1]}
+		for i := 2; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				if _, ok := s.get(args[0]); ok {
+					return nil
+				}
+			case "PX":
+				if i+1 >= len(args) {
+					return redisArgumentsError(name)
+				}
+				milliseconds, e := strconv.ParseInt(args[i+1], 10, 64)
+				if e != nil || milliseconds <= 0 {
+					return errors.New("ERR invalid expire time in 'set' command")
+				}
+				value.expires = time.Now().Add(time.Duration(milliseconds) * time.Millisecond)
+				i++
+			default:
+				return errors.New("ERR syntax error")
+			}
+		}
+		s.set(args[0], value)
+		return "OK"
+	case "INCR":
+		if len(args) != 1 {
+			return redisArgumentsError(name)
+		}
+		value, _ := s.get(args[0])
+		count := int64(0)
+		if value.value != "" {
+			var e error
+			count, e = strconv.ParseInt(value.value, 10, 64)
+			if e != nil {
+				return errors.New("ERR value is not an integer or out of range")
+			}
+		}
+		count++
+		value.value = strconv.FormatInt(count, 10)
+		s.set(args[0], value)
+		return count
+	case "DEL":
+		deleted := int64(0)
+		for _, key := range args {
+			if _, ok := s.get(key); ok {
+				s.del(key)
+				deleted++
+			}
+		}
+		return deleted
+	case "PEXPIRE":
+		if len(args) != 2 {
+			return redisArgumentsError(name)
+		}
+		milliseconds, e := strconv.ParseInt(args[1], 10, 64)
+		if e != nil {
+			return errors.New("ERR value is not an integer or out of range")
+		}
+		value, ok := s.get(args[0])
+		if !ok {
+			return int64(0)
+		}
+		value.expires = time.Now().Add(time.Duration(milliseconds) * time.Millisecond)
+		s.set(args[0], value)
+		return int64(1)
+	case "PTTL":
+		if len(args) != 1 {
+			return redisArgumentsError(name)
+		}
+		value, ok := s.get(args[0])
+		if !ok {
+			return int64(-2)
+		}
+		if value.expires.IsZero() {
+			return int64(-1)
+		}
+		return int64(time.Until(value.expires) / time.Millisecond)
+	}
+
+	return errors.New("ERR unknown command '" + command[0] + "'")
+}
+
+// get removes expired keys as redis does, which counts as a change for WATCH
+func (s *RedisServer) get(key string) (redisValue, bool) {
+	value, ok := s.values[key]
+	if !ok {
+		return redisValue{}, false
+	}
+	if !value.expires.IsZero() && !value.expires.After(time.Now()) {
+		s.del(key)
+		return redisValue{}, false
+	}
+
+	return value, true
+}
+
+func (s *RedisServer) set(key string, value redisValue) {
+	s.values[key] = value
+	s.versions[key]++
+}
+
+func (s *RedisServer) del(key string) {
+	delete(s.values, key)
+	s.versions[key]++
+}
+
+func redisArgumentsError(name string) error {
+	return errors.New("ERR wrong number of arguments for '" + strings.ToLower(name) + "' command")
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, e := readRedisLine(reader)
+	if e != nil {
+		return nil, e
+	}
+	if !strings.HasPrefix(line, "*") {
+		// inline commands, as typed in to telnet
+		return strings.Fields(line), nil
+	}
+
+	count, e := strconv.Atoi(line[1:])
+	if e != nil {
+		return nil, e
+	}
+	command := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		line, e = readRedisLine(reader)
+		if e != nil {
+			return nil, e
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, errors.New("expected a bulk string")
+		}
+		size, e := strconv.Atoi(line[1:])
+		if e != nil {
+			return nil, e
+		}
+		bulk := make([]byte, size+2)
+		_, e = io.ReadFull(reader, bulk)
+		if e != nil {
+			return nil, e
+		}
+		command = append(command, string(bulk[:size]))
+	}
+
+	return command, nil
+}
+
+func readRedisLine(reader *bufio.Reader) (string, error) {
+	line, e := reader.ReadString('\n')
+	if e != nil {
+		return "", e
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// writeRedisReply writes strings as simple strings and []byte as bulk strings
+func writeRedisReply(writer *bufio.Writer, reply interface{}) {
+	switch reply := reply.(type) {
+	case nil:
+		_, _ = writer.WriteString("$-1\r\n")
+	case string:
+		_, _ = writer.WriteString("+" + reply + "\r\n")
+	case []byte:
+		_, _ = writer.WriteString("$" + strconv.Itoa(len(reply)) + "\r\n" + string(reply) + "\r\n")
+	case int64:
+		_, _ = writer.WriteString(":" + strconv.FormatInt(reply, 10) + "\r\n")
+	case error:
+		_, _ = writer.WriteString("-" + reply.Error() + "\r\n")
+	case []interface{}:
+		if reply == nil {
+			_, _ = writer.WriteString("*-1\r\n")
+			return
+		}
+		_, _ = writer.WriteString("*" + strconv.Itoa(len(reply)) + "\r\n")
+		for _, item := range reply {
+			writeRedisReply(writer, item)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package cbwebtest_test
+
+import (
+	"bufio"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type redisConn struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialRedis(t *testing.T, server *cbwebtest.RedisServer) *redisConn {
+	conn, e := net.Dial("tcp", server.Address)
+	if e != nil {
+		t.Fatal(e)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return &redisConn{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// do sends the command and returns the reply as the raw RESP lines joined with spaces
+func (c *redisConn) do(args ...string) string {
+	c.t.Helper()
+
+	command := "*" + strconv.Itoa(len(args)) + "\r\n"
+	for _, arg := range args {
+		command += "$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n"
+	}
+	if _, e := c.conn.Write([]byte(command)); e != nil {
+		c.t.Fatal(e)
+	}
+
+	return strings.Join(c.read(), " ")
+}
+
+func (c *redisConn) read() []string {
+	c.t.Helper()
+
+	line, e := c.reader.ReadString('\n')
+	if e != nil {
+		c.t.Fatal(e)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	lines := []string{line}
+
+	switch line[0] {
+	case '$':
+		if line != "$-1" {
+			value, _ := c.reader.ReadString('\n')
+			lines = append(lines, strings.TrimRight(value, "\r\n"))
+		}
+	case '*':
+		count, _ := strconv.Atoi(line[1:])
+		for i := 0; i < count; i++ {
+			lines = append(lines, c.read()...)
+		}
+	}
+
+	return lines
+}
+
+func TestRedisServerCommands(t *testing.T) {
+	server := cbwebtest.NewRedisServer(t)
+	conn := dialRedis(t, server)
+
+	tests := []struct {
+		command  []string
+		expected string
+	}{
+		{command: []string{"PING"}, expected: "+PONG"},
+		{command: []string{"AUTH", "password"}, expected: "+OK"},
+		{command: []string{"GET", "missing"}, expected: "$-1"},
+		{command: []string{"SET", "name", "ada"}, expected: "+OK"},
+		{command: []string{"GET", "name"}, expected: "$3 ada"},
+		{command: []string{"SET", "name", "grace", "NX"}, expected: "$-1"},
+		{command: []string{"SET", "other", "grace", "NX", "PX", "60000"}, expected: "+OK"},
+		{command: []string{"PTTL", "name"}, expected: ":-1"},
+		{command: []string{"PTTL", "missing"}, expected: ":-2"},
+		{command: []string{"INCR", "count"}, expected: ":1"},
+		{command: []string{"INCR", "count"}, expected: ":2"},
+		{command: []string{"INCR", "name"}, expected: "-ERR value is not an integer or out of range"},
+		{command: []string{"PEXPIRE", "count", "60000"}, expected: ":1"},
+		{command: []string{"PEXPIRE", "missing", "60000"}, expected: ":0"},
+		{command: []string{"DEL", "count", "missing"}, expected: ":1"},
+		{command: []string{"SET", "name"}, expected: "-ERR wrong number of arguments for 'set' command"},
+		{command: []string{"SET", "name", "ada", "PX", "0"}, expected: "-ERR invalid expire time in 'set' command"},
+		{command: []string{"EXEC"}, expected: "-ERR EXEC without MULTI"},
+		{command: []string{"HGET", "name"}, expected: "-ERR unknown command 'HGET'"},
+	}
+
+	for _, test := range tests {
+		if reply := conn.do(test.command...); reply != test.expected {
+			t.Errorf("%v: expected %q, got %q", test.command, test.expected, reply)
+		}
+	}
+
+	if keys := server.Keys(); !reflect.DeepEqual(keys, []string{"name", "other"}) {
+		t.Errorf("expected the keys name and other, got %v", keys)
+	}
+	server.FlushAll()
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("expected no keys after FlushAll, got %v", keys)
+	}
+}
+
+func TestRedisServerExpiry(t *testing.T) {
+	server := cbwebtest.NewRedisServer(t)
+	conn := dialRedis(t, server)
+
+	conn.do("SET", "short", "lived", "PX", "5")
+	time.Sleep(time.Millisecond * 20)
+
+	if reply := conn.do("GET", "short"); reply != "$-1" {
+		t.Errorf("expected the key to have expired, got %q", reply)
+	}
+}
+
+func TestRedisServerTransactions(t *testing.T) {
+	server := cbwebtest.NewRedisServer(t)
+	conn := dialRedis(t, server)
+	other := dialRedis(t, server)
+
+	// an untouched watched key lets the transaction run
+	conn.do("WATCH", "key")
+	conn.do("MULTI")
+	if reply := conn.do("SET", "key", "first"); reply != "+QUEUED" {
+		t.Fatalf("expected the command to be queued, got %q", reply)
+	}
+	conn.do("INCR", "count")
+	if reply := conn.do("EXEC"); reply != "*2 +OK :1" {
+		t.Errorf("expected both replies, got %q", reply)
+	}
+
+	// another connection changing a watched key aborts the transaction
+	conn.do("WATCH", "key")
+	other.do("SET", "key", "changed")
+	conn.do("MULTI")
+	conn.do("SET", "key", "second")
+	if reply := conn.do("EXEC"); reply != "*-1" {
+		t.Errorf("expected the transaction to abort, got %q", reply)
+	}
+	if reply := conn.do("GET", "key"); reply != "$7 changed" {
+		t.Errorf("expected the other connection's value, got %q", reply)
+	}
+
+	// UNWATCH forgets the watched keys
+	conn.do("WATCH", "key")
+	other.do("SET", "key", "again")
+	conn.do("UNWATCH")
+	conn.do("MULTI")
+	conn.do("SET", "key", "third")
+	if reply := conn.do("EXEC"); reply != "*1 +OK" {
+		t.Errorf("expected the transaction to run after UNWATCH, got %q", reply)
+	}
+
+	conn.do("MULTI")
+	if reply := conn.do("WATCH", "key"); reply != "-ERR WATCH inside MULTI is not allowed" {
+		t.Errorf("expected WATCH inside MULTI to fail, got %q", reply)
+	}
+	conn.do("SET", "key", "discarded")
+	if reply := conn.do("DISCARD"); reply != "+OK" {
+		t.Errorf("expected DISCARD to succeed, got %q", reply)
+	}
+	if reply := conn.do("GET", "key"); reply != "$5 third" {
+		t.Errorf("expected the discarded SET not to run, got %q", reply)
+	}
+}
@@ -0,0 +1,217 @@
+package cbwebtest
+
+import (
+	"bytes"
+	"github.com/codingbeard/cbweb"
+	"github.com/valyala/fasthttp"
+	"html/template"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+var flashTemplate = template.Must(template.New("flash").Parse(
+	`<div class="chip {{ .Type }}">{{ .Message }}<i class="close material-icons">close</i></div>`,
+))
+
+// the toast is rendered inside a script tag so it gets the same js escaping, the tags are trimmed off before comparing
+var flashToastTemplate = template.Must(template.New("flashtoast").Parse(
+	`<script type="text/javascript">M.toast({html: {{.Message}}, classes: {{.Type}}});</script>`,
+))
+
+type Response struct {
+	StatusCode int
+	Header     fasthttp.ResponseHeader
+	Body       []byte
+	// Redirects holds the Location of every redirect that was followed to get this response
+	Redirects []string
+	Method    string
+	Uri       string
+	host      string
+	t         testing.TB
+}
+
+func newResponse(t testing.TB, req *fasthttp.Request, resp *fasthttp.Response, redirects []string) *Response {
+	response := &Response{
+		StatusCode: resp.StatusCode(),
+		Body:       append([]byte(nil), resp.Body()...),
+		Redirects:  redirects,
+		Method:     string(req.Header.Method()),
+		Uri:        string(req.RequestURI()),
+		host:       string(req.Header.Host()),
+		t:          t,
+	}
+	resp.Header.CopyTo(&response.Header)
+
+	return response
+}
+
+func (r *Response) GetHeader(name string) string {
+	return string(r.Header.Peek(name))
+}
+
+func (r *Response) GetBodyString() string {
+	return string(r.Body)
+}
+
+func (r *Response) describe() string {
+	return r.Method + " " + r.Uri
+}
+
+func (r *Response) AssertStatus(statusCode int) *Response {
+	r.t.Helper()
+	if r.StatusCode != statusCode {
+		r.t.Errorf("%s: expected status %d, got %d", r.describe(), statusCode, r.StatusCode)
+	}
+
+	return r
+}
+
+func (r *Response) AssertHeader(name, value string) *Response {
+	r.t.Helper()
+	if actual := r.GetHeader(name); actual != value {
+		r.t.Errorf("%s: expected header %s to be %q, got %q", r.describe(), name, value, actual)
+	}
+
+	return r
+}
+
+func (r *Response) AssertHeaderContains(name, value string) *Response {
+	r.t.Helper()
+	if actual := r.GetHeader(name); !strings.Contains(actual, value) {
+		r.t.Errorf("%s: expected header %s to contain %q, got %q", r.describe(), name, value, actual)
+	}
+
+	return r
+}
+
+func (r *Response) AssertNoHeader(name string) *Response {
+	r.t.Helper()
+	if r.Header.Peek(name) != nil {
+		r.t.Errorf("%s: expected no header %s, got %q", r.describe(), name, r.GetHeader(name))
+	}
+
+	return r
+}
+
+// AssertRedirect checks the response is a redirect to location, it needs FollowRedirects to be off.
+// Absolute locations on the harness host match their path, as fasthttp makes redirect locations absolute.
+func (r *Response) AssertRedirect(location string) *Response {
+	r.t.Helper()
+	if !isRedirect(r.StatusCode) {
+		r.t.Errorf("%s: expected a redirect to %q, got status %d", r.describe(), location, r.StatusCode)
+		return r
+	}
+
+	actual := r.GetHeader("Location")
+	for _, scheme := range []string{"http://", "https://"} {
+		if strings.HasPrefix(actual, scheme+r.host+"/") && !strings.HasPrefix(location, scheme) {
+			actual = strings.TrimPrefix(actual, scheme+r.host)
+		}
+	}
+	if actual != location {
+		r.t.Errorf("%s: expected a redirect to %q, got %q", r.describe(), location, actual)
+	}
+
+	return r
+}
+
+// AssertRedirectedTo checks the last redirect that was followed went to location
+func (r *Response) AssertRedirectedTo(location string) *Response {
+	r.t.Helper()
+	if len(r.Redirects) == 0 {
+		r.t.Errorf("%s: expected to have been redirected to %q, got no redirects", r.describe(), location)
+		return r
+	}
+	if last := r.Redirects[len(r.Redirects)-1]; last != location {
+		r.t.Errorf("%s: expected to have been redirected to %q, got %q", r.describe(), location, last)
+	}
+
+	return r
+}
+
+func (r *Response) AssertBodyContains(fragment string) *Response {
+	r.t.Helper()
+	if !bytes.Contains(r.Body, []byte(fragment)) {
+		r.t.Errorf("%s: expected body to contain %q, got:\n%s", r.describe(), fragment, r.Body)
+	}
+
+	return r
+}
+
+func (r *Response) AssertBodyNotContains(fragment string) *Response {
+	r.t.Helper()
+	if bytes.Contains(r.Body, []byte(fragment)) {
+		r.t.Errorf("%s: expected body not to contain %q, got:\n%s", r.describe(), fragment, r.Body)
+	}
+
+	return r
+}
+
+// AssertHtmlContains checks the rendered html contains fragment, ignoring differences in whitespace
+func (r *Response) AssertHtmlContains(fragment string) *Response {
+	r.t.Helper()
+	if !strings.Contains(normaliseHtml(string(r.Body)), normaliseHtml(fragment)) {
+		r.t.Errorf("%s: expected html to contain %q, got:\n%s", r.describe(), fragment, r.Body)
+	}
+
+	return r
+}
+
+func (r *Response) AssertHtmlNotContains(fragment string) *Response {
+	r.t.Helper()
+	if strings.Contains(normaliseHtml(string(r.Body)), normaliseHtml(fragment)) {
+		r.t.Errorf("%s: expected html not to contain %q, got:\n%s", r.describe(), fragment, r.Body)
+	}
+
+	return r
+}
+
+// AssertFlash checks the message was rendered by the cbwebcommon flash or flashtoast templates
+func (r *Response) AssertFlash(message cbweb.FlashMessage) *Response {
+	r.t.Helper()
+	if !r.hasFlash(message) {
+		r.t.Errorf("%s: expected flash %s %q to be rendered, got:\n%s", r.describe(), message.Type, message.Message, r.Body)
+	}
+
+	return r
+}
+
+func (r *Response) AssertNoFlash(message cbweb.FlashMessage) *Response {
+	r.t.Helper()
+	if r.hasFlash(message) {
+		r.t.Errorf("%s: expected flash %s %q not to be rendered, got:\n%s", r.describe(), message.Type, message.Message, r.Body)
+	}
+
+	return r
+}
+
+func (r *Response) hasFlash(message cbweb.FlashMessage) bool {
+	body := normaliseHtml(string(r.Body))
+	for _, flashTemplate := range []*template.Template{flashTemplate, flashToastTemplate} {
+		var expected bytes.Buffer
+		if flashTemplate.Execute(&expected, message) != nil {
+			continue
+		}
+		fragment := expected.String()
+		if flashTemplate == flashToastTemplate {
+			fragment = strings.TrimSuffix(strings.TrimPrefix(fragment, `<script type="text/javascript">`), `</script>`)
+		}
+		if strings.Contains(body, normaliseHtml(fragment)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normaliseHtml collapses runs of whitespace and drops whitespace next to tags
+func normaliseHtml(html string) string {
+	html = whitespaceRegex.ReplaceAllString(strings.TrimSpace(html), " ")
+	html = strings.Replace(html, "> ", ">", -1)
+	html = strings.Replace(html, " <", "<", -1)
+
+	return html
+}
@@ -0,0 +1,208 @@
+package cbweb
+
+import (
+	"bytes"
+	"github.com/klauspost/compress/zstd"
+	"github.com/valyala/fasthttp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	CompressionEncoding_Brotli = "br"
+	CompressionEncoding_Zstd   = "zstd"
+	CompressionEncoding_Gzip   = "gzip"
+	DefaultCompressionTypes    = []string{
+		"text/html",
+		"text/css",
+		"text/plain",
+		"text/javascript",
+		"text/json",
+		"text/xml",
+		"application/javascript",
+		"application/json",
+		"application/xml",
+		"application/manifest+json",
+		"image/svg+xml",
+	}
+)
+
+type CompressionConfig struct {
+	// MinSize is the smallest body worth compressing, defaults to 1024 bytes
+	MinSize int
+	// MaxStreamSize is the largest body stream, such as a file from DefaultFileServer, which is read in to compress
+	MaxStreamSize int
+	// ContentTypes are the compressible media types, anything else such as images and archives is left as is
+	ContentTypes []string
+	// Encodings in order of preference when the client accepts several equally
+	Encodings []string
+	GzipLevel int
+	// BrotliLevel defaults to fasthttp.CompressBrotliDefaultCompression when nil, 0 is brotli's fastest level
+	BrotliLevel *int
+	ZstdLevel   zstd.EncoderLevel
+}
+
+type Compression struct {
+	config       CompressionConfig
+	contentTypes map[string]bool
+	brotliLevel  int
+	zstdEncoder  *zstd.Encoder
+	zstdError    error
+	zstdOnce     sync.Once
+}
+
+func NewCompression(config CompressionConfig) *Compression {
+	if config.MinSize == 0 {
+		config.MinSize = 1024
+	}
+	if config.MaxStreamSize == 0 {
+		config.MaxStreamSize = 4 * 1024 * 1024
+	}
+	if len(config.ContentTypes) == 0 {
+		config.ContentTypes = DefaultCompressionTypes
+	}
+	if len(config.Encodings) == 0 {
+		config.Encodings = []string{CompressionEncoding_Brotli, CompressionEncoding_Zstd, CompressionEncoding_Gzip}
+	}
+	if config.GzipLevel == 0 {
+		config.GzipLevel = fasthttp.CompressDefaultCompression
+	}
+	if config.ZstdLevel == 0 {
+		config.ZstdLevel = zstd.SpeedDefault
+	}
+
+	contentTypes := make(map[string]bool)
+	for _, contentType := range config.ContentTypes {
+		contentTypes[strings.ToLower(contentType)] = true
+	}
+
+	brotliLevel := fasthttp.CompressBrotliDefaultCompression
+	if config.BrotliLevel != nil {
+		brotliLevel = *config.BrotliLevel
+	}
+
+	return &Compression{config: config, contentTypes: contentTypes, brotliLevel: brotliLevel}
+}
+
+// AfterFinal compresses the response body, add it with MiddlewareHandler.SetAfterFinal
+func (c *Compression) AfterFinal(ctx *fasthttp.RequestCtx) (bool, error) {
+	if !c.isCompressible(ctx) {
+		return true, nil
+	}
+	ctx.Response.Header.Add(fasthttp.HeaderVary, "Accept-Encoding")
+
+	encoding := c.negotiate(string(ctx.Request.Header.Peek(fasthttp.HeaderAcceptEncoding)))
+	if encoding == "" {
+		return true, nil
+	}
+
+	if ctx.Response.IsBodyStream() {
+		contentLength := ctx.Response.Header.ContentLength()
+		if contentLength < c.config.MinSize || contentLength > c.config.MaxStreamSize {
+			return true, nil
+		}
+	}
+
+	body := ctx.Response.Body()
+	if len(body) < c.config.MinSize {
+		return true, nil
+	}
+
+	var compressed []byte
+	switch encoding {
+	case CompressionEncoding_Brotli:
+		compressed = fasthttp.AppendBrotliBytesLevel(nil, body, c.brotliLevel)
+	case CompressionEncoding_Gzip:
+		compressed = fasthttp.AppendGzipBytesLevel(nil, body, c.config.GzipLevel)
+	case CompressionEncoding_Zstd:
+		encoder, e := c.getZstdEncoder()
+		if e != nil {
+			return true, e
+		}
+		compressed = encoder.EncodeAll(body, nil)
+	}
+	if len(compressed) >= len(body) {
+		return true, nil
+	}
+
+	ctx.Response.SetBodyRaw(compressed)
+	ctx.Response.Header.Set(fasthttp.HeaderContentEncoding, encoding)
+	// the encoded body is no longer byte identical to the one a strong tag was computed from
+	if etag := ctx.Response.Header.Peek(fasthttp.HeaderETag); len(etag) > 0 && !bytes.HasPrefix(etag, []byte("W/")) {
+		ctx.Response.Header.Set(fasthttp.HeaderETag, "W/"+string(etag))
+	}
+
+	return true, nil
+}
+
+func (c *Compression) isCompressible(ctx *fasthttp.RequestCtx) bool {
+	if ctx.IsHead() {
+		return false
+	}
+	status := ctx.Response.StatusCode()
+	if status < 200 || status == fasthttp.StatusNoContent || status == fasthttp.StatusNotModified {
+		return false
+	}
+	if len(ctx.Response.Header.Peek(fasthttp.HeaderContentEncoding)) > 0 {
+		return false
+	}
+
+	contentType := strings.ToLower(string(ctx.Response.Header.ContentType()))
+	if index := strings.Index(contentType, ";"); index != -1 {
+		contentType = contentType[:index]
+	}
+
+	return c.contentTypes[strings.TrimSpace(contentType)]
+}
+
+// negotiate picks the accepted encoding with the highest q value, ties are broken by the configured preference
+func (c *Compression) negotiate(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		encoding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if encoding == "" {
+			continue
+		}
+		quality := 1.0
+		for _, parameter := range fields[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if strings.HasPrefix(parameter, "q=") {
+				parsed, e := strconv.ParseFloat(parameter[2:], 64)
+				if e == nil {
+					quality = parsed
+				}
+			}
+		}
+		if encoding == "*" {
+			wildcard = quality
+			continue
+		}
+		qualities[encoding] = quality
+	}
+
+	best := ""
+	bestQuality := 0.0
+	for _, encoding := range c.config.Encodings {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			best = encoding
+			bestQuality = quality
+		}
+	}
+
+	return best
+}
+
+func (c *Compression) getZstdEncoder() (*zstd.Encoder, error) {
+	c.zstdOnce.Do(func() {
+		c.zstdEncoder, c.zstdError = zstd.NewWriter(nil, zstd.WithEncoderLevel(c.config.ZstdLevel))
+	})
+
+	return c.zstdEncoder, c.zstdError
+}
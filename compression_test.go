@@ -0,0 +1,137 @@
+package cbweb_test
+
+import (
+	"bytes"
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/klauspost/compress/zstd"
+	"github.com/valyala/fasthttp"
+	"strings"
+	"testing"
+)
+
+var compressionBody = []byte(strings.Repeat("<p>compress me</p>", 200))
+
+func newCompressionHarness(t *testing.T, config cbweb.CompressionConfig) *cbwebtest.Harness {
+	compression := cbweb.NewCompression(config)
+	handle := func(contentType string, body []byte, etag string) fasthttp.RequestHandler {
+		return cbweb.MiddlewareHandler{}.
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				ctx.SetContentType(contentType)
+				if etag != "" {
+					ctx.Response.Header.Set(fasthttp.HeaderETag, etag)
+				}
+				ctx.SetBody(body)
+			}).
+			SetAfterFinal(compression.AfterFinal).
+			Handle
+	}
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.GET("/html", handle("text/html; charset=utf-8", compressionBody, ""))
+		r.GET("/png", handle("image/png", compressionBody, ""))
+		r.GET("/small", handle("text/html; charset=utf-8", compressionBody[:100], ""))
+		r.GET("/strong", handle("text/html; charset=utf-8", compressionBody, `"v1"`))
+		r.GET("/weak", handle("text/html; charset=utf-8", compressionBody, `W/"v1"`))
+	}})
+}
+
+func getCompressed(h *cbwebtest.Harness, path, acceptEncoding string) *cbwebtest.Response {
+	return h.Do(fasthttp.MethodGet, path, nil, map[string]string{fasthttp.HeaderAcceptEncoding: acceptEncoding})
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	h := newCompressionHarness(t, cbweb.CompressionConfig{})
+
+	tests := map[string]string{
+		"":                                 "",
+		"identity":                         "",
+		"gzip":                             "gzip",
+		"gzip, deflate, br":                "br",
+		"gzip, zstd":                       "zstd",
+		"br;q=0.5, gzip":                   "gzip",
+		"br;q=0, zstd;q=0, gzip":           "gzip",
+		"GZIP":                             "gzip",
+		"*":                                "br",
+		"*;q=0.5, gzip":                    "gzip",
+		"*, br;q=0":                        "zstd",
+		"gzip;q=0":                         "",
+		"deflate, compress;q=0.9":          "",
+		"br;q=0.8, zstd;q=0.8, gzip;q=0.9": "gzip",
+	}
+	for acceptEncoding, expected := range tests {
+		t.Run(acceptEncoding, func(t *testing.T) {
+			response := getCompressed(h, "/html", acceptEncoding).AssertHeaderContains(fasthttp.HeaderVary, "Accept-Encoding")
+			if encoding := response.GetHeader(fasthttp.HeaderContentEncoding); encoding != expected {
+				t.Fatalf("expected %q to be encoded with %q, got %q", acceptEncoding, expected, encoding)
+			}
+
+			var decoded []byte
+			var e error
+			switch expected {
+			case "":
+				decoded = response.Body
+			case "br":
+				decoded, e = fasthttp.AppendUnbrotliBytes(nil, response.Body)
+			case "gzip":
+				decoded, e = fasthttp.AppendGunzipBytes(nil, response.Body)
+			case "zstd":
+				var decoder *zstd.Decoder
+				decoder, e = zstd.NewReader(nil)
+				if e == nil {
+					decoded, e = decoder.DecodeAll(response.Body, nil)
+					decoder.Close()
+				}
+			}
+			if e != nil {
+				t.Fatal(e)
+			}
+			if !bytes.Equal(decoded, compressionBody) {
+				t.Errorf("expected the decoded body to match the original")
+			}
+		})
+	}
+}
+
+func TestCompressionContentTypes(t *testing.T) {
+	h := newCompressionHarness(t, cbweb.CompressionConfig{})
+
+	getCompressed(h, "/html", "gzip").AssertHeader(fasthttp.HeaderContentEncoding, "gzip")
+	// images are already compressed and small bodies are not worth it
+	getCompressed(h, "/png", "gzip").AssertNoHeader(fasthttp.HeaderContentEncoding).AssertNoHeader(fasthttp.HeaderVary)
+	getCompressed(h, "/small", "gzip").AssertNoHeader(fasthttp.HeaderContentEncoding)
+
+	h = newCompressionHarness(t, cbweb.CompressionConfig{ContentTypes: []string{"image/png"}})
+	getCompressed(h, "/png", "gzip").AssertHeader(fasthttp.HeaderContentEncoding, "gzip")
+	getCompressed(h, "/html", "gzip").AssertNoHeader(fasthttp.HeaderContentEncoding)
+}
+
+func TestCompressionWeakensETag(t *testing.T) {
+	h := newCompressionHarness(t, cbweb.CompressionConfig{})
+
+	getCompressed(h, "/strong", "gzip").AssertHeader(fasthttp.HeaderETag, `W/"v1"`)
+	getCompressed(h, "/weak", "gzip").AssertHeader(fasthttp.HeaderETag, `W/"v1"`)
+	// an uncompressed body is still byte identical
+	getCompressed(h, "/strong", "identity").AssertHeader(fasthttp.HeaderETag, `"v1"`)
+}
+
+func TestCompressionBrotliLevel(t *testing.T) {
+	bestSpeed := fasthttp.CompressBrotliBestSpeed
+	tests := map[string]struct {
+		level    *int
+		expected int
+	}{
+		"default":    {nil, fasthttp.CompressBrotliDefaultCompression},
+		"best speed": {&bestSpeed, fasthttp.CompressBrotliBestSpeed},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := newCompressionHarness(t, cbweb.CompressionConfig{BrotliLevel: test.level})
+			response := getCompressed(h, "/html", "br").AssertHeader(fasthttp.HeaderContentEncoding, "br")
+			if expected := fasthttp.AppendBrotliBytesLevel(nil, compressionBody, test.expected); !bytes.Equal(response.Body, expected) {
+				t.Errorf("expected the body to be compressed at level %d", test.expected)
+			}
+		})
+	}
+}
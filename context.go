@@ -0,0 +1,128 @@
+package cbweb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/valyala/fasthttp"
+	"time"
+)
+
+var RequestIdHeader = "X-Request-ID"
+
+// ContextKey namespaces a request scoped value stored with ctx.SetUserValue,
+// so it cannot collide with router params or other packages' values
+type ContextKey struct {
+	name string
+}
+
+func NewContextKey(name string) ContextKey {
+	return ContextKey{name: name}
+}
+
+func (k ContextKey) Set(ctx *fasthttp.RequestCtx, value interface{}) {
+	ctx.SetUserValue(k, value)
+}
+
+func (k ContextKey) Get(ctx *fasthttp.RequestCtx) interface{} {
+	return ctx.UserValue(k)
+}
+
+func (k ContextKey) Has(ctx *fasthttp.RequestCtx) bool {
+	return ctx.UserValue(k) != nil
+}
+
+func (k ContextKey) String() string {
+	return "cbweb." + k.name
+}
+
+var (
+	requestIdContextKey = NewContextKey("requestId")
+	userContextKey      = NewContextKey("user")
+	timingsContextKey   = NewContextKey("timings")
+)
+
+type Timing struct {
+	Name     string
+	Duration time.Duration
+}
+
+func SetRequestId(ctx *fasthttp.RequestCtx, requestId string) {
+	requestIdContextKey.Set(ctx, requestId)
+}
+
+func GetRequestId(ctx *fasthttp.RequestCtx) string {
+	if requestId, ok := requestIdContextKey.Get(ctx).(string); ok {
+		return requestId
+	}
+
+	return ""
+}
+
+func SetUser(ctx *fasthttp.RequestCtx, user interface{}) {
+	userContextKey.Set(ctx, user)
+}
+
+func GetUser(ctx *fasthttp.RequestCtx) interface{} {
+	return userContextKey.Get(ctx)
+}
+
+func AddTiming(ctx *fasthttp.RequestCtx, name string, duration time.Duration) {
+	timings, ok := timingsContextKey.Get(ctx).(*[]Timing)
+	if !ok {
+		timings = &[]Timing{}
+		timingsContextKey.Set(ctx, timings)
+	}
+	*timings = append(*timings, Timing{Name: name, Duration: duration})
+}
+
+func GetTimings(ctx *fasthttp.RequestCtx) []Timing {
+	if timings, ok := timingsContextKey.Get(ctx).(*[]Timing); ok {
+		return *timings
+	}
+
+	return nil
+}
+
+// RequestIdMiddleware keeps a well formed X-Request-ID sent by the client or generates a new one,
+// storing it on the context and echoing it in the response
+func RequestIdMiddleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if GetRequestId(ctx) != "" {
+		return true, nil
+	}
+
+	requestId := string(ctx.Request.Header.Peek(RequestIdHeader))
+	if !isValidRequestId(requestId) {
+		requestId = NewRequestId()
+	}
+
+	SetRequestId(ctx, requestId)
+	ctx.Response.Header.Set(RequestIdHeader, requestId)
+
+	return true, nil
+}
+
+func NewRequestId() string {
+	id := make([]byte, 16)
+	_, e := rand.Read(id)
+	if e != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
+
+func isValidRequestId(requestId string) bool {
+	if len(requestId) == 0 || len(requestId) > 128 {
+		return false
+	}
+	for _, char := range requestId {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '-' || char == '_' || char == '.' || char == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,191 @@
+package cbweb
+
+import (
+	"errors"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CorsConfig struct {
+	// AllowedOrigins are exact origins, origins with one * wildcard such as https://*.example.com, or * for any
+	AllowedOrigins        []string
+	AllowedOriginPatterns []*regexp.Regexp
+	AllowedMethods        []string
+	// AllowedHeaders are the request headers a preflight may ask for, * allows any
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Cors is a policy which can be shared by a group of routes or created per route
+type Cors struct {
+	config         CorsConfig
+	allowedOrigins []string
+	allowedMethods map[string]bool
+	allowedHeaders map[string]bool
+	anyOrigin      bool
+	anyHeader      bool
+	preflights     map[*router.Router]map[string]bool
+	preflightsLock sync.Mutex
+}
+
+// NewCors returns an error for AllowCredentials with the * origin, which would let any site make credentialed requests
+func NewCors(config CorsConfig) (*Cors, error) {
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = []string{fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodPost}
+	}
+	if len(config.AllowedHeaders) == 0 {
+		config.AllowedHeaders = []string{"Content-Type"}
+	}
+
+	cors := &Cors{
+		config:         config,
+		allowedMethods: make(map[string]bool),
+		allowedHeaders: make(map[string]bool),
+		preflights:     make(map[*router.Router]map[string]bool),
+	}
+	for _, method := range config.AllowedMethods {
+		cors.allowedMethods[strings.ToUpper(method)] = true
+	}
+	for _, header := range config.AllowedHeaders {
+		if header == "*" {
+			cors.anyHeader = true
+		}
+		cors.allowedHeaders[strings.ToLower(header)] = true
+	}
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			cors.anyOrigin = true
+		}
+		// schemes and hosts are case insensitive
+		cors.allowedOrigins = append(cors.allowedOrigins, strings.ToLower(origin))
+	}
+	if cors.anyOrigin && config.AllowCredentials {
+		return nil, errors.New("cors AllowCredentials cannot be used with the * origin, list the allowed origins instead")
+	}
+
+	return cors, nil
+}
+
+func (c *Cors) IsOriginAllowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if c.anyOrigin {
+		return true
+	}
+	lowerOrigin := strings.ToLower(origin)
+	for _, allowed := range c.allowedOrigins {
+		if wildcard := strings.Index(allowed, "*"); wildcard != -1 {
+			prefix, suffix := allowed[:wildcard], allowed[wildcard+1:]
+			if len(lowerOrigin) > len(prefix)+len(suffix) && strings.HasPrefix(lowerOrigin, prefix) && strings.HasSuffix(lowerOrigin, suffix) {
+				return true
+			}
+		} else if allowed == lowerOrigin {
+			return true
+		}
+	}
+	for _, pattern := range c.config.AllowedOriginPatterns {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Middleware adds the CORS response headers for allowed origins, it never halts as the browser enforces the policy
+func (c *Cors) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	ctx.Response.Header.Add(fasthttp.HeaderVary, "Origin")
+
+	origin := string(ctx.Request.Header.Peek("Origin"))
+	if !c.IsOriginAllowed(origin) {
+		return true, nil
+	}
+
+	c.setAllowOrigin(ctx, origin)
+	if len(c.config.ExposedHeaders) > 0 {
+		ctx.Response.Header.Set("Access-Control-Expose-Headers", strings.Join(c.config.ExposedHeaders, ", "))
+	}
+
+	return true, nil
+}
+
+// Preflight answers OPTIONS requests, disallowed preflights get a 204 without CORS headers so the browser blocks them
+func (c *Cors) Preflight(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.Add(fasthttp.HeaderVary, "Origin")
+	ctx.Response.Header.Add(fasthttp.HeaderVary, "Access-Control-Request-Method")
+	ctx.Response.Header.Add(fasthttp.HeaderVary, "Access-Control-Request-Headers")
+	ctx.SetStatusCode(fasthttp.StatusNoContent)
+
+	origin := string(ctx.Request.Header.Peek("Origin"))
+	if !c.IsOriginAllowed(origin) {
+		return
+	}
+
+	method := strings.ToUpper(string(ctx.Request.Header.Peek("Access-Control-Request-Method")))
+	if !c.allowedMethods[method] {
+		return
+	}
+
+	var requestedHeaders []string
+	for _, header := range strings.Split(string(ctx.Request.Header.Peek("Access-Control-Request-Headers")), ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !c.anyHeader && !c.allowedHeaders[strings.ToLower(header)] {
+			return
+		}
+		requestedHeaders = append(requestedHeaders, header)
+	}
+
+	c.setAllowOrigin(ctx, origin)
+	ctx.Response.Header.Set("Access-Control-Allow-Methods", strings.Join(c.config.AllowedMethods, ", "))
+	if len(requestedHeaders) > 0 {
+		ctx.Response.Header.Set("Access-Control-Allow-Headers", strings.Join(requestedHeaders, ", "))
+	}
+	if c.config.MaxAge > 0 {
+		ctx.Response.Header.Set("Access-Control-Max-Age", strconv.Itoa(int(c.config.MaxAge/time.Second)))
+	}
+}
+
+// Handle registers handler with the CORS headers applied and an OPTIONS preflight for the path,
+// the preflight is only registered once per path so several methods can share it. RouteGroup.WithCors
+// registers a group's routes through it
+func (c *Cors) Handle(routes *router.Router, method, path string, handler fasthttp.RequestHandler) {
+	routes.Handle(method, path, func(ctx *fasthttp.RequestCtx) {
+		_, _ = c.Middleware(ctx)
+		handler(ctx)
+	})
+
+	c.preflightsLock.Lock()
+	defer c.preflightsLock.Unlock()
+	if c.preflights[routes] == nil {
+		c.preflights[routes] = make(map[string]bool)
+	}
+	// a route of its own for OPTIONS answers the preflight itself
+	if !c.preflights[routes][path] && method != fasthttp.MethodOptions {
+		routes.OPTIONS(path, c.Preflight)
+	}
+	c.preflights[routes][path] = true
+}
+
+func (c *Cors) setAllowOrigin(ctx *fasthttp.RequestCtx, origin string) {
+	// the request's origin is never reflected for *, so credentials can not be allowed for every site
+	if c.anyOrigin {
+		ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+
+	ctx.Response.Header.Set("Access-Control-Allow-Origin", origin)
+	if c.config.AllowCredentials {
+		ctx.Response.Header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
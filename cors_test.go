@@ -0,0 +1,117 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"testing"
+)
+
+func newCorsHarness(t *testing.T, config cbweb.CorsConfig) *cbwebtest.Harness {
+	cors, e := cbweb.NewCors(config)
+	if e != nil {
+		t.Fatal(e)
+	}
+	module := &routesModule{routes: func(r *router.Router) {
+		group := cbweb.NewRouteGroup(r, "/api", cbweb.MiddlewareHandler{}).WithCors(cors)
+		group.GET("/items", func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("items")
+		})
+		group.POST("/items", func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("created")
+		})
+	}}
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, module)
+}
+
+func TestCorsPreflight(t *testing.T) {
+	h := newCorsHarness(t, cbweb.CorsConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{fasthttp.MethodGet, fasthttp.MethodPost},
+		AllowedHeaders:   []string{"Content-Type", "X-Requested-With"},
+		AllowCredentials: true,
+	})
+
+	h.Do(fasthttp.MethodOptions, "/api/items", nil, map[string]string{
+		"Origin":                         "https://app.example.com",
+		"Access-Control-Request-Method":  fasthttp.MethodPost,
+		"Access-Control-Request-Headers": "content-type, x-requested-with",
+	}).
+		AssertStatus(fasthttp.StatusNoContent).
+		AssertHeader("Access-Control-Allow-Origin", "https://app.example.com").
+		AssertHeader("Access-Control-Allow-Credentials", "true").
+		AssertHeader("Access-Control-Allow-Methods", "GET, POST").
+		AssertHeader("Access-Control-Allow-Headers", "content-type, x-requested-with")
+
+	denied := map[string]map[string]string{
+		"origin": {
+			"Origin":                        "https://evil.example.org",
+			"Access-Control-Request-Method": fasthttp.MethodPost,
+		},
+		"method": {
+			"Origin":                        "https://app.example.com",
+			"Access-Control-Request-Method": fasthttp.MethodDelete,
+		},
+		"header": {
+			"Origin":                         "https://app.example.com",
+			"Access-Control-Request-Method":  fasthttp.MethodPost,
+			"Access-Control-Request-Headers": "Authorization",
+		},
+	}
+	for name, headers := range denied {
+		t.Run(name, func(t *testing.T) {
+			h.Do(fasthttp.MethodOptions, "/api/items", nil, headers).
+				AssertStatus(fasthttp.StatusNoContent).
+				AssertNoHeader("Access-Control-Allow-Origin").
+				AssertNoHeader("Access-Control-Allow-Methods")
+		})
+	}
+
+	h.Do(fasthttp.MethodGet, "/api/items", nil, map[string]string{"Origin": "https://app.example.com"}).
+		AssertStatus(fasthttp.StatusOK).
+		AssertHeader("Access-Control-Allow-Origin", "https://app.example.com").
+		AssertBodyContains("items")
+}
+
+func TestCorsCredentialsWithAnyOrigin(t *testing.T) {
+	_, e := cbweb.NewCors(cbweb.CorsConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	if e == nil {
+		t.Fatal("expected an error for AllowCredentials with the * origin")
+	}
+
+	h := newCorsHarness(t, cbweb.CorsConfig{AllowedOrigins: []string{"*"}})
+	h.Do(fasthttp.MethodGet, "/api/items", nil, map[string]string{"Origin": "https://anywhere.example.org"}).
+		AssertHeader("Access-Control-Allow-Origin", "*").
+		AssertNoHeader("Access-Control-Allow-Credentials")
+}
+
+func TestCorsWildcardOrigins(t *testing.T) {
+	cors, e := cbweb.NewCors(cbweb.CorsConfig{
+		AllowedOrigins: []string{"https://*.Example.com", "https://App.example.org"},
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	origins := map[string]bool{
+		"https://api.example.com":      true,
+		"https://API.EXAMPLE.COM":      true,
+		"https://a.b.example.com":      true,
+		"https://app.example.org":      true,
+		"HTTPS://APP.EXAMPLE.ORG":      true,
+		"https://example.com":          false,
+		"https://.example.com":         false,
+		"http://api.example.com":       false,
+		"https://api.example.com.evil": false,
+		"https://evilexample.com":      false,
+		"https://app.example.org.evil": false,
+		"":                             false,
+	}
+	for origin, allowed := range origins {
+		if cors.IsOriginAllowed(origin) != allowed {
+			t.Errorf("expected IsOriginAllowed(%q) to be %v", origin, allowed)
+		}
+	}
+}
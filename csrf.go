@@ -0,0 +1,215 @@
+package cbweb
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/codingbeard/cbweb/cbform"
+	"github.com/valyala/fasthttp"
+	"html/template"
+	"time"
+)
+
+var csrfContextKey = NewContextKey("csrf")
+
+type CsrfConfig struct {
+	CookieName  string
+	FieldName   string
+	HeaderName  string
+	CookiePath  string
+	CookieTtl   time.Duration
+	Secure      bool
+	SafeMethods []string
+	// Failure renders the rejection, defaults to a plain 403
+	Failure func(ctx *fasthttp.RequestCtx)
+}
+
+// Csrf implements double submit cookie protection: a random token is kept in a cookie and unsafe requests
+// must echo it back in a form field or header, which a cross site request cannot read to do
+type Csrf struct {
+	config      CsrfConfig
+	safeMethods map[string]bool
+}
+
+// CsrfToken is what templates need to submit the token, NewMasterViewModel sets it as DefaultMasterViewModel.Csrf
+type CsrfToken struct {
+	Token      string
+	FieldName  string
+	HeaderName string
+	CookieName string
+}
+
+func NewCsrf(config CsrfConfig) *Csrf {
+	if config.CookieName == "" {
+		config.CookieName = "cbweb-csrf"
+	}
+	if config.FieldName == "" {
+		config.FieldName = "csrf_token"
+	}
+	if config.HeaderName == "" {
+		config.HeaderName = "X-CSRF-Token"
+	}
+	if config.CookiePath == "" {
+		config.CookiePath = "/"
+	}
+	if config.CookieTtl == 0 {
+		config.CookieTtl = time.Hour * 24 * 365
+	}
+	if len(config.SafeMethods) == 0 {
+		config.SafeMethods = []string{
+			fasthttp.MethodGet,
+			fasthttp.MethodHead,
+			fasthttp.MethodOptions,
+			fasthttp.MethodTrace,
+		}
+	}
+	if config.Failure == nil {
+		config.Failure = func(ctx *fasthttp.RequestCtx) {
+			ctx.SetStatusCode(fasthttp.StatusForbidden)
+			ctx.SetBodyString("Error: 403 Invalid CSRF Token")
+		}
+	}
+
+	safeMethods := make(map[string]bool)
+	for _, method := range config.SafeMethods {
+		safeMethods[method] = true
+	}
+
+	return &Csrf{config: config, safeMethods: safeMethods}
+}
+
+func (c *Csrf) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if !c.Verify(ctx) {
+		c.config.Failure(ctx)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Verify sets up the token like Middleware and reports whether the request may go ahead without rendering
+// the Failure, handlers which report their own errors such as dbauth's Login use it
+func (c *Csrf) Verify(ctx *fasthttp.RequestCtx) bool {
+	token := GetCsrfToken(ctx).Token
+	if token == "" {
+		token = c.setToken(ctx)
+	}
+
+	if c.safeMethods[string(ctx.Method())] {
+		return true
+	}
+
+	submitted := c.getSubmittedToken(ctx)
+
+	return submitted != "" && subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) == 1
+}
+
+func (c *Csrf) setToken(ctx *fasthttp.RequestCtx) string {
+	token := string(ctx.Request.Header.Cookie(c.config.CookieName))
+	if !isValidCsrfToken(token) {
+		token = newCsrfToken()
+		var cookie fasthttp.Cookie
+		cookie.SetKey(c.config.CookieName)
+		cookie.SetValue(token)
+		cookie.SetPath(c.config.CookiePath)
+		cookie.SetExpire(time.Now().Add(c.config.CookieTtl))
+		cookie.SetHTTPOnly(true)
+		cookie.SetSecure(c.config.Secure)
+		cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+		ctx.Response.Header.SetCookie(&cookie)
+	}
+
+	csrfContextKey.Set(ctx, CsrfToken{
+		Token:      token,
+		FieldName:  c.config.FieldName,
+		HeaderName: c.config.HeaderName,
+		CookieName: c.config.CookieName,
+	})
+
+	return token
+}
+
+func (c *Csrf) getSubmittedToken(ctx *fasthttp.RequestCtx) string {
+	if header := ctx.Request.Header.Peek(c.config.HeaderName); len(header) > 0 {
+		return string(header)
+	}
+	if field := ctx.PostArgs().Peek(c.config.FieldName); len(field) > 0 {
+		return string(field)
+	}
+	if form, e := ctx.MultipartForm(); e == nil {
+		if values := form.Value[c.config.FieldName]; len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+func GetCsrfToken(ctx *fasthttp.RequestCtx) CsrfToken {
+	if token, ok := csrfContextKey.Get(ctx).(CsrfToken); ok {
+		return token
+	}
+
+	return CsrfToken{}
+}
+
+// GetCsrfField returns a hidden form field carrying the token, the Name is empty when csrf is not in use
+func GetCsrfField(ctx *fasthttp.RequestCtx) cbform.Field {
+	token := GetCsrfToken(ctx)
+	if token.Token == "" {
+		return cbform.Field{}
+	}
+
+	return cbform.Field{
+		Name:  token.FieldName,
+		Type:  "hidden",
+		Value: token.Token,
+	}
+}
+
+// NewForm builds a cbform.Container which includes the hidden csrf field when the Csrf middleware has run
+func NewForm(ctx *fasthttp.RequestCtx, fields ...cbform.Field) *cbform.Container {
+	form := cbform.New(fields...)
+	if field := GetCsrfField(ctx); field.Name != "" {
+		form.AddField(field)
+	}
+
+	return form
+}
+
+func (t CsrfToken) Input() template.HTML {
+	if t.Token == "" {
+		return ""
+	}
+
+	return template.HTML(`<input type="hidden" name="` + template.HTMLEscapeString(t.FieldName) + `" value="` + template.HTMLEscapeString(t.Token) + `"/>`)
+}
+
+// Meta exposes the token to javascript, master.gohtml uses it to add the header to jQuery ajax requests
+func (t CsrfToken) Meta() template.HTML {
+	if t.Token == "" {
+		return ""
+	}
+
+	return template.HTML(`<meta name="csrf-header" content="` + template.HTMLEscapeString(t.HeaderName) + `">` +
+		`<meta name="csrf-token" content="` + template.HTMLEscapeString(t.Token) + `">`)
+}
+
+func newCsrfToken() string {
+	token := make([]byte, 32)
+	_, e := rand.Read(token)
+	if e != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(token)
+}
+
+func isValidCsrfToken(token string) bool {
+	if len(token) != 64 {
+		return false
+	}
+	_, e := hex.DecodeString(token)
+
+	return e == nil
+}
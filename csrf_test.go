@@ -0,0 +1,71 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"net/url"
+	"testing"
+)
+
+func newCsrfHarness(t *testing.T) *cbwebtest.Harness {
+	csrf := cbweb.NewCsrf(cbweb.CsrfConfig{})
+	handler := cbweb.MiddlewareHandler{}.
+		AddMiddleware(csrf.Middleware).
+		SetFinal(func(ctx *fasthttp.RequestCtx) {
+			ctx.SetContentType("text/html")
+			ctx.SetBodyString(`<form method="post">` + string(cbweb.NewMasterViewModel(ctx).CsrfField()) + `</form>`)
+		})
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.ANY("/form", handler.Handle)
+	}})
+}
+
+func TestCsrfMiddleware(t *testing.T) {
+	h := newCsrfHarness(t)
+
+	// safe methods pass and are given a token
+	for _, method := range []string{fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodOptions} {
+		h.Do(method, "/form", nil, nil).AssertStatus(fasthttp.StatusOK)
+	}
+	token := h.GetCookie("cbweb-csrf")
+	if len(token) != 64 {
+		t.Fatalf("expected a csrf cookie, got %q", token)
+	}
+	h.Get("/form").AssertBodyContains(`<input type="hidden" name="csrf_token" value="` + token + `"/>`)
+	if h.GetCookie("cbweb-csrf") != token {
+		t.Error("expected the token to be kept between requests")
+	}
+
+	tests := []struct {
+		name    string
+		values  url.Values
+		headers map[string]string
+		status  int
+	}{
+		{name: "missing token", values: url.Values{"name": {"ada"}}, status: fasthttp.StatusForbidden},
+		{name: "mismatched form token", values: url.Values{"csrf_token": {token[1:] + "0"}}, status: fasthttp.StatusForbidden},
+		{name: "mismatched header token", headers: map[string]string{"X-CSRF-Token": "nope"}, status: fasthttp.StatusForbidden},
+		{name: "form token", values: url.Values{"csrf_token": {token}}, status: fasthttp.StatusOK},
+		{name: "header token", headers: map[string]string{"X-CSRF-Token": token}, status: fasthttp.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
+			for name, value := range test.headers {
+				headers[name] = value
+			}
+			response := h.Do(fasthttp.MethodPost, "/form", []byte(test.values.Encode()), headers).AssertStatus(test.status)
+			if test.status == fasthttp.StatusForbidden {
+				response.AssertBodyContains("Error: 403 Invalid CSRF Token")
+			}
+		})
+	}
+
+	// a token without the matching cookie is refused, a cross site page cannot set the cookie
+	h.ClearCookies()
+	h.PostForm("/form", url.Values{"csrf_token": {token}}).AssertStatus(fasthttp.StatusForbidden)
+}
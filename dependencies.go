@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"github.com/valyala/fasthttp"
 	"log"
 	"runtime"
 	"strings"
@@ -15,18 +16,47 @@ type ErrorHandler interface {
 	Recover()
 }
 
+// CtxErrorHandler is an ErrorHandler that can tie errors back to the request they happened in
+type CtxErrorHandler interface {
+	ErrorCtx(ctx *fasthttp.RequestCtx, e error)
+}
+
+// HandleError passes the error to ErrorCtx when the handler implements CtxErrorHandler, otherwise to Error
+func HandleError(handler ErrorHandler, ctx *fasthttp.RequestCtx, e error) {
+	if handler == nil {
+		return
+	}
+	if ctxHandler, ok := handler.(CtxErrorHandler); ok && ctx != nil {
+		ctxHandler.ErrorCtx(ctx, e)
+		return
+	}
+	handler.Error(e)
+}
+
 type DefaultErrorHandler struct{}
 
 func (d DefaultErrorHandler) Error(e error) {
+	d.log(e.Error())
+}
+
+func (d DefaultErrorHandler) ErrorCtx(ctx *fasthttp.RequestCtx, e error) {
+	message := string(ctx.Method()) + " " + string(ctx.RequestURI())
+	if requestId := GetRequestId(ctx); requestId != "" {
+		message += " request_id=" + requestId
+	}
+	d.log(message + ": " + e.Error())
+}
+
+func (d DefaultErrorHandler) log(message string) {
 	buf := make([]byte, 1000000)
 	runtime.Stack(buf, false)
 	buf = bytes.Trim(buf, "\x00")
 	stack := string(buf)
 	stackParts := strings.Split(stack, "\n")
 	newStackParts := []string{stackParts[0]}
-	newStackParts = append(newStackParts, stackParts[3:]...)
+	newStackParts = append(newStackParts, stackParts[5:]...)
 	stack = strings.Join(newStackParts, "\n")
-	log.Println("ERROR", e.Error()+"\n"+stack)
+	log.Println("ERROR", message+"\n"+stack)
 }
 
 func (d DefaultErrorHandler) Recover() {
@@ -0,0 +1,132 @@
+package cbweb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/valyala/fasthttp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ETagConfig struct {
+	// Weak marks generated tags as weak, use it when equivalent but not byte identical responses are expected
+	Weak bool
+	// CurrentETag returns the tag of the resource's current state for If-Match checks on unsafe requests,
+	// an empty string means the resource does not exist
+	CurrentETag func(ctx *fasthttp.RequestCtx) string
+}
+
+// ETag adds tags to dynamic responses and answers conditional requests for them
+type ETag struct {
+	config ETagConfig
+}
+
+func NewETag(config ETagConfig) *ETag {
+	return &ETag{config: config}
+}
+
+// Middleware answers 412 Precondition Failed when an unsafe request's If-Match does not match CurrentETag
+func (e *ETag) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if e.config.CurrentETag == nil || ctx.IsGet() || ctx.IsHead() {
+		return true, nil
+	}
+
+	ifMatch := string(ctx.Request.Header.Peek(fasthttp.HeaderIfMatch))
+	if ifMatch == "" {
+		return true, nil
+	}
+
+	current := e.config.CurrentETag(ctx)
+	if current == "" || !ETagMatches(ifMatch, current, false) {
+		ctx.SetStatusCode(fasthttp.StatusPreconditionFailed)
+		ctx.SetBodyString("Error: 412 Precondition Failed")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// AfterFinal tags successful GET and HEAD responses and turns them in to 304 Not Modified
+// when the client's If-None-Match already has the tag. Add it before Compression.AfterFinal so the
+// tag is of the plain body, an encoded body is decoded to hash it and the tag marked weak.
+// Bodies carrying this request's csp nonce are never tagged, a 304 would send the new policy with
+// the browser's cached body and its old nonce, blocking every inline script and style
+func (e *ETag) AfterFinal(ctx *fasthttp.RequestCtx) (bool, error) {
+	if (!ctx.IsGet() && !ctx.IsHead()) || ctx.Response.StatusCode() != fasthttp.StatusOK {
+		return true, nil
+	}
+
+	etag := string(ctx.Response.Header.Peek(fasthttp.HeaderETag))
+	if !ctx.Response.IsBodyStream() {
+		body, err := ctx.Response.BodyUncompressed()
+		if err != nil && etag == "" {
+			// an encoding fasthttp cannot decode, such as zstd, is left untagged
+			return true, nil
+		}
+		if nonce := GetCspNonce(ctx); nonce != "" && bytes.Contains(body, []byte(nonce)) {
+			return true, nil
+		}
+		if etag == "" {
+			etag = ComputeETag(body, e.config.Weak || len(ctx.Response.Header.Peek(fasthttp.HeaderContentEncoding)) > 0)
+			ctx.Response.Header.Set(fasthttp.HeaderETag, etag)
+		}
+	} else if etag == "" {
+		return true, nil
+	}
+
+	if ETagMatches(string(ctx.Request.Header.Peek(fasthttp.HeaderIfNoneMatch)), etag, true) {
+		// ctx.NotModified would reset the headers, a 304 must still carry the tag and any caching headers
+		ctx.Response.ResetBody()
+		ctx.SetStatusCode(fasthttp.StatusNotModified)
+	}
+
+	return true, nil
+}
+
+func ComputeETag(body []byte, weak bool) string {
+	hash := sha256.Sum256(body)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(hash[:18]) + `"`
+	if weak {
+		return "W/" + etag
+	}
+
+	return etag
+}
+
+// FileETag builds a tag from a file's modification time and size, like most static file servers do
+func FileETag(modified time.Time, size int64) string {
+	return `"` + strconv.FormatInt(modified.Unix(), 16) + "-" + strconv.FormatInt(size, 16) + `"`
+}
+
+// ETagMatches checks a If-Match or If-None-Match header value against etag, weak comparison ignores
+// the W/ prefix as If-None-Match requires while If-Match needs strong comparison
+func ETagMatches(header string, etag string, weak bool) bool {
+	header = strings.TrimSpace(header)
+	if header == "" || etag == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	if !weak && strings.HasPrefix(etag, "W/") {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = candidate[2:]
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"testing"
+)
+
+func newETagHarness(t *testing.T) *cbwebtest.Harness {
+	etag := cbweb.NewETag(cbweb.ETagConfig{
+		CurrentETag: func(ctx *fasthttp.RequestCtx) string {
+			return `"v1"`
+		},
+	})
+	securityHeaders := cbweb.NewSecurityHeaders(cbweb.SecurityHeadersConfig{})
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.GET("/page", cbweb.MiddlewareHandler{}.
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyString("<p>hello</p>")
+			}).
+			SetAfterFinal(etag.AfterFinal).
+			Handle,
+		)
+		r.GET("/nonce", cbweb.MiddlewareHandler{}.
+			AddMiddleware(securityHeaders.Middleware).
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyString(`<script nonce="` + cbweb.GetCspNonce(ctx) + `"></script>`)
+			}).
+			SetAfterFinal(etag.AfterFinal).
+			Handle,
+		)
+		r.PUT("/page", cbweb.MiddlewareHandler{}.
+			AddMiddleware(etag.Middleware).
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyString("updated")
+			}).
+			Handle,
+		)
+	}})
+}
+
+func TestETagNotModified(t *testing.T) {
+	h := newETagHarness(t)
+
+	first := h.Get("/page").AssertStatus(fasthttp.StatusOK)
+	tag := first.GetHeader(fasthttp.HeaderETag)
+	if tag != cbweb.ComputeETag([]byte("<p>hello</p>"), false) {
+		t.Fatalf("expected a strong tag of the body, got %q", tag)
+	}
+
+	response := h.Do(fasthttp.MethodGet, "/page", nil, map[string]string{fasthttp.HeaderIfNoneMatch: tag}).
+		AssertStatus(fasthttp.StatusNotModified).
+		AssertHeader(fasthttp.HeaderETag, tag)
+	if len(response.Body) != 0 {
+		t.Errorf("expected a 304 without a body, got %q", response.Body)
+	}
+
+	h.Do(fasthttp.MethodGet, "/page", nil, map[string]string{fasthttp.HeaderIfNoneMatch: `"stale"`}).
+		AssertStatus(fasthttp.StatusOK).
+		AssertBodyContains("hello")
+}
+
+func TestETagSkipsNoncePages(t *testing.T) {
+	h := newETagHarness(t)
+
+	h.Get("/nonce").
+		AssertStatus(fasthttp.StatusOK).
+		AssertNoHeader(fasthttp.HeaderETag)
+
+	h.Do(fasthttp.MethodGet, "/nonce", nil, map[string]string{fasthttp.HeaderIfNoneMatch: "*"}).
+		AssertStatus(fasthttp.StatusOK).
+		AssertBodyContains("<script nonce=")
+}
+
+func TestETagIfMatch(t *testing.T) {
+	h := newETagHarness(t)
+
+	tests := []struct {
+		ifMatch string
+		status  int
+	}{
+		{ifMatch: "", status: fasthttp.StatusOK},
+		{ifMatch: `"v1"`, status: fasthttp.StatusOK},
+		{ifMatch: `"v0", "v1"`, status: fasthttp.StatusOK},
+		{ifMatch: "*", status: fasthttp.StatusOK},
+		{ifMatch: `"v2"`, status: fasthttp.StatusPreconditionFailed},
+		// If-Match needs the strong comparison
+		{ifMatch: `W/"v1"`, status: fasthttp.StatusPreconditionFailed},
+	}
+
+	for _, test := range tests {
+		headers := map[string]string{}
+		if test.ifMatch != "" {
+			headers[fasthttp.HeaderIfMatch] = test.ifMatch
+		}
+		response := h.Do(fasthttp.MethodPut, "/page", nil, headers).AssertStatus(test.status)
+		if test.status == fasthttp.StatusPreconditionFailed {
+			response.AssertBodyContains("Error: 412 Precondition Failed")
+		}
+	}
+}
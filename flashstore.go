@@ -0,0 +1,236 @@
+package cbweb
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/valyala/fasthttp"
+	"strings"
+	"time"
+)
+
+var (
+	flashContextKey = NewContextKey("flash")
+	// a cookie over this size may be dropped by the browser, messages are dropped until it fits
+	flashMaxCookieSize = 3800
+)
+
+type FlashStoreConfig struct {
+	// Secret signs the messages kept in the cookie, it is required unless Cache is set
+	Secret string
+	// Cache keeps the messages server side, the cookie then only holds a random id
+	Cache      CacheProvider
+	KeyPrefix  string
+	CookieName string
+	CookiePath string
+	Secure     bool
+	// Ttl is how long unread messages are kept, defaults to 10 minutes
+	Ttl time.Duration
+}
+
+type flashState struct {
+	flash  *Flash
+	loaded string
+	id     string
+}
+
+// FlashStore keeps flash messages between requests so they survive a redirect. Add Middleware and Always
+// to the handler, messages are saved at the end of the request until GetMessages has rendered them
+type FlashStore struct {
+	config FlashStoreConfig
+}
+
+func NewFlashStore(config FlashStoreConfig) (*FlashStore, error) {
+	if config.Secret == "" && config.Cache == nil {
+		return nil, errors.New("flash store needs a Secret or a Cache")
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "cbweb-flash:"
+	}
+	if config.CookieName == "" {
+		config.CookieName = "cbweb-flash"
+	}
+	if config.CookiePath == "" {
+		config.CookiePath = "/"
+	}
+	if config.Ttl == 0 {
+		config.Ttl = time.Minute * 10
+	}
+
+	return &FlashStore{config: config}, nil
+}
+
+// Middleware loads the messages saved by earlier requests, GetFlash returns them
+func (s *FlashStore) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	state := &flashState{flash: &Flash{}}
+	cookie := string(ctx.Request.Header.Cookie(s.config.CookieName))
+
+	if cookie != "" {
+		if s.config.Cache != nil {
+			state.id = cookie
+			if value, ok := s.config.Cache.Get(s.config.KeyPrefix + cookie); ok {
+				state.loaded, _ = value.(string)
+			}
+		} else {
+			state.loaded = s.verify(cookie)
+		}
+		if state.loaded != "" {
+			_ = json.Unmarshal([]byte(state.loaded), &state.flash.Messages)
+		}
+	}
+
+	flashContextKey.Set(ctx, state)
+
+	return true, nil
+}
+
+// Always saves the messages which were not rendered, or clears them once they all have been
+func (s *FlashStore) Always(ctx *fasthttp.RequestCtx, outcome Outcome) {
+	state, ok := flashContextKey.Get(ctx).(*flashState)
+	if !ok || outcome.TimedOut {
+		return
+	}
+
+	payload := encodeFlash(state.flash)
+	if payload == state.loaded {
+		return
+	}
+
+	if payload == "" {
+		if s.config.Cache != nil && state.id != "" {
+			s.config.Cache.Delete(s.config.KeyPrefix + state.id)
+		}
+		s.setCookie(ctx, "", -time.Hour)
+		return
+	}
+
+	if s.config.Cache != nil {
+		if state.id == "" {
+			state.id = newFlashId()
+		}
+		s.config.Cache.Set(s.config.KeyPrefix+state.id, payload, s.config.Ttl)
+		s.setCookie(ctx, state.id, s.config.Ttl)
+		return
+	}
+
+	cookie := s.sign(payload)
+	for len(cookie) > flashMaxCookieSize && dropFlashMessage(state.flash) {
+		cookie = s.sign(encodeFlash(state.flash))
+	}
+	s.setCookie(ctx, cookie, s.config.Ttl)
+}
+
+func (s *FlashStore) setCookie(ctx *fasthttp.RequestCtx, value string, ttl time.Duration) {
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(s.config.CookieName)
+	cookie.SetValue(value)
+	cookie.SetPath(s.config.CookiePath)
+	cookie.SetHTTPOnly(true)
+	cookie.SetSecure(s.config.Secure)
+	cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+	cookie.SetExpire(time.Now().Add(ttl))
+	ctx.Response.Header.SetCookie(cookie)
+}
+
+func (s *FlashStore) sign(payload string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.getSignature(encoded))
+}
+
+// verify returns the payload of a cookie signed with the Secret, or an empty string
+func (s *FlashStore) verify(cookie string) string {
+	parts := strings.SplitN(cookie, ".", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	signature, e := base64.RawURLEncoding.DecodeString(parts[1])
+	if e != nil || !hmac.Equal(signature, s.getSignature(parts[0])) {
+		return ""
+	}
+	payload, e := base64.RawURLEncoding.DecodeString(parts[0])
+	if e != nil {
+		return ""
+	}
+
+	return string(payload)
+}
+
+func (s *FlashStore) getSignature(encoded string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.Secret))
+	_, _ = mac.Write([]byte("flash:" + encoded))
+
+	return mac.Sum(nil)
+}
+
+// GetFlash returns the request's flash messages, NewMasterViewModel sets it as DefaultMasterViewModel.Flash. Messages added
+// before a redirect are shown on the next page rendered when the FlashStore middleware is in use
+func GetFlash(ctx *fasthttp.RequestCtx) *Flash {
+	if state, ok := flashContextKey.Get(ctx).(*flashState); ok {
+		return state.flash
+	}
+
+	state := &flashState{flash: &Flash{}}
+	flashContextKey.Set(ctx, state)
+
+	return state.flash
+}
+
+// hasFlashMessages reports whether the request loaded or added messages, the response is specific to the user
+func hasFlashMessages(ctx *fasthttp.RequestCtx) bool {
+	state, ok := flashContextKey.Get(ctx).(*flashState)
+
+	return ok && (state.loaded != "" || encodeFlash(state.flash) != "")
+}
+
+func encodeFlash(flash *Flash) string {
+	messages := make(map[string][]FlashMessage)
+	for group, groupMessages := range flash.Messages {
+		if len(groupMessages) > 0 {
+			messages[group] = groupMessages
+		}
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+
+	// json sorts map keys so the same messages always encode the same way
+	payload, e := json.Marshal(messages)
+	if e != nil {
+		return ""
+	}
+
+	return string(payload)
+}
+
+// dropFlashMessage removes the newest message of the largest group, it returns false when there are none left
+func dropFlashMessage(flash *Flash) bool {
+	largest := ""
+	for group, messages := range flash.Messages {
+		if len(messages) > len(flash.Messages[largest]) || (largest == "" && len(messages) > 0) {
+			largest = group
+		}
+	}
+	if len(flash.Messages[largest]) == 0 {
+		return false
+	}
+	flash.Messages[largest] = flash.Messages[largest][:len(flash.Messages[largest])-1]
+
+	return true
+}
+
+func newFlashId() string {
+	id := make([]byte, 16)
+	_, e := rand.Read(id)
+	if e != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,109 @@
+package cbweb_test
+
+import (
+	"encoding/base64"
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"strings"
+	"testing"
+)
+
+func newFlashStoreHarness(t *testing.T, config cbweb.FlashStoreConfig) *cbwebtest.Harness {
+	store, e := cbweb.NewFlashStore(config)
+	if e != nil {
+		t.Fatal(e)
+	}
+	handle := func(final func(ctx *fasthttp.RequestCtx)) fasthttp.RequestHandler {
+		return cbweb.MiddlewareHandler{}.
+			AddMiddleware(store.Middleware).
+			AddAlways(store.Always).
+			SetFinal(final).
+			Handle
+	}
+
+	return cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.POST("/save", handle(func(ctx *fasthttp.RequestCtx) {
+			cbweb.GetFlash(ctx).AddMessage("default", cbweb.FlashMessage{Type: "green", Message: "Saved"})
+			ctx.Redirect("/show", fasthttp.StatusFound)
+		}))
+		r.GET("/show", handle(func(ctx *fasthttp.RequestCtx) {
+			var messages []string
+			for _, message := range cbweb.GetFlash(ctx).GetMessages("default") {
+				messages = append(messages, message.Type+": "+message.Message)
+			}
+			ctx.SetBodyString("messages: " + strings.Join(messages, ", "))
+		}))
+		r.GET("/elsewhere", handle(func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("no messages rendered")
+		}))
+	}})
+}
+
+func TestFlashStoreSurvivesRedirect(t *testing.T) {
+	configs := map[string]cbweb.FlashStoreConfig{
+		"signed cookie": {Secret: "secret"},
+		"cache":         {Cache: cbwebtest.NewCache()},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			h := newFlashStoreHarness(t, config)
+
+			h.PostForm("/save", nil).
+				AssertRedirectedTo("/show").
+				AssertBodyContains("messages: green: Saved")
+			if cookie := h.GetCookie("cbweb-flash"); cookie != "" {
+				t.Errorf("expected the cookie to be cleared once the messages were rendered, got %q", cookie)
+			}
+			h.Get("/show").AssertBodyContains("messages: ").AssertBodyNotContains("Saved")
+
+			// messages wait for a page which renders them
+			h.FollowRedirects = false
+			h.PostForm("/save", nil).AssertRedirect("/show")
+			h.Get("/elsewhere").AssertStatus(fasthttp.StatusOK)
+			h.Get("/show").AssertBodyContains("messages: green: Saved")
+		})
+	}
+}
+
+func TestFlashStoreRejectsTamperedCookie(t *testing.T) {
+	h := newFlashStoreHarness(t, cbweb.FlashStoreConfig{Secret: "secret"})
+	h.FollowRedirects = false
+
+	h.PostForm("/save", nil).AssertRedirect("/show")
+	cookie := h.GetCookie("cbweb-flash")
+	parts := strings.SplitN(cookie, ".", 2)
+	if len(parts) != 2 {
+		t.Fatalf("expected a signed cookie, got %q", cookie)
+	}
+
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"default":[{"Type":"red","Message":"Forged"}]}`))
+	tests := map[string]string{
+		"payload":   tampered + "." + parts[1],
+		"signature": parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte("forged signature")),
+		"unsigned":  parts[0],
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			h.SetCookie("cbweb-flash", value)
+			h.Get("/show").
+				AssertBodyNotContains("Forged").
+				AssertBodyNotContains("Saved")
+		})
+	}
+
+	// a cookie signed with another secret is rejected too
+	other := newFlashStoreHarness(t, cbweb.FlashStoreConfig{Secret: "other"})
+	other.SetCookie("cbweb-flash", cookie)
+	other.Get("/show").AssertBodyNotContains("Saved")
+
+	h.SetCookie("cbweb-flash", cookie)
+	h.Get("/show").AssertBodyContains("messages: green: Saved")
+}
+
+func TestFlashStoreNeedsSecretOrCache(t *testing.T) {
+	if _, e := cbweb.NewFlashStore(cbweb.FlashStoreConfig{}); e == nil {
+		t.Fatal("expected an error without a Secret or Cache")
+	}
+}
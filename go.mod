@@ -1,16 +1,17 @@
 module github.com/codingbeard/cbweb
 
-go 1.13
+go 1.14
 
 require (
 	github.com/codingbeard/checkmail v0.0.0-20181210160741-9661bd69e9ad
 	github.com/didip/tollbooth v1.0.0
 	github.com/didip/tollbooth_fasthttp v0.0.0-20170910065828-cfa276ddefe2
 	github.com/fasthttp/router v1.4.19
-	github.com/golang-jwt/jwt v3.2.2+incompatible // indirect
+	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/jinzhu/gorm v1.9.16
+	github.com/klauspost/compress v1.16.3
 	github.com/patrickmn/go-cache v2.1.0+incompatible // indirect
 	github.com/valyala/fasthttp v1.47.0
-	golang.org/x/crypto v0.10.0 // indirect
+	golang.org/x/crypto v0.10.0
 	golang.org/x/time v0.0.0-20191024005414-555d28b269f0 // indirect
 )
@@ -0,0 +1,270 @@
+package cbweb
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/codingbeard/cbweb/cbform"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/valyala/fasthttp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	idempotencyContextKey     = NewContextKey("idempotency")
+)
+
+type IdempotencyConfig struct {
+	Cache CacheProvider
+	// Ttl is how long a response is replayed for, defaults to 24 hours
+	Ttl time.Duration
+	// InFlightTtl expires the in flight marker of a request whose process died before finishing, defaults to 1 minute
+	InFlightTtl time.Duration
+	KeyPrefix   string
+	HeaderName  string
+	FieldName   string
+	// Methods defaults to POST and PATCH
+	Methods []string
+	// Auth scopes keys to the authenticated user so one user can never replay another's response
+	Auth *cbwebauth.Container
+	// Required rejects requests without a key with 400 Bad Request
+	Required bool
+}
+
+type idempotencyInFlight struct {
+	Fingerprint string
+}
+
+type idempotentResponse struct {
+	Fingerprint string
+	Response    *CachedResponse
+	// Cookies are the Set-Cookie headers, which a retried POST and redirect needs for its flash messages
+	Cookies []string
+}
+
+type idempotencyRequest struct {
+	key         string
+	fingerprint string
+}
+
+// Idempotency records the first response for an Idempotency-Key and replays it for duplicates. Add
+// Middleware after csrf and auth checks, and Always to the same handler so the response is recorded
+// however the chain ended. Add Always after FlashStore.Always so the flash cookie is recorded with it.
+// Only responses below 400 are recorded, the key can be retried after an error
+type Idempotency struct {
+	config  IdempotencyConfig
+	methods map[string]bool
+	// CacheProvider has no atomic add, the lock makes the in flight check safe within this process
+	lock sync.Mutex
+}
+
+func NewIdempotency(config IdempotencyConfig) *Idempotency {
+	if config.Ttl == 0 {
+		config.Ttl = time.Hour * 24
+	}
+	if config.InFlightTtl == 0 {
+		config.InFlightTtl = time.Minute
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "cbweb-idempotency:"
+	}
+	if config.HeaderName == "" {
+		config.HeaderName = "Idempotency-Key"
+	}
+	if config.FieldName == "" {
+		config.FieldName = "idempotency_key"
+	}
+	if len(config.Methods) == 0 {
+		config.Methods = []string{fasthttp.MethodPost, fasthttp.MethodPatch}
+	}
+
+	methods := make(map[string]bool)
+	for _, method := range config.Methods {
+		methods[method] = true
+	}
+
+	return &Idempotency{config: config, methods: methods}
+}
+
+func (i *Idempotency) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if !i.methods[string(ctx.Method())] {
+		return true, nil
+	}
+
+	submitted := i.getSubmittedKey(ctx)
+	if submitted == "" {
+		if i.config.Required {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetBodyString("Error: 400 " + i.config.HeaderName + " Required")
+			return false, nil
+		}
+		return true, nil
+	}
+
+	key := i.getKey(ctx, submitted)
+	fingerprint := i.getFingerprint(ctx)
+
+	i.lock.Lock()
+	value, ok := i.config.Cache.Get(key)
+	if !ok {
+		i.config.Cache.Set(key, &idempotencyInFlight{Fingerprint: fingerprint}, i.config.InFlightTtl)
+	}
+	i.lock.Unlock()
+
+	if !ok {
+		idempotencyContextKey.Set(ctx, idempotencyRequest{key: key, fingerprint: fingerprint})
+		return true, nil
+	}
+
+	switch stored := value.(type) {
+	case *idempotentResponse:
+		if stored.Fingerprint != fingerprint {
+			i.reuseError(ctx)
+			return false, nil
+		}
+		ctx.SetStatusCode(stored.Response.Status)
+		setCachedHeaders(ctx, stored.Response.Headers)
+		ctx.SetBody(stored.Response.Body)
+		setIdempotentCookies(ctx, stored.Cookies)
+		ctx.Response.Header.Set(IdempotencyReplayedHeader, "true")
+	case *idempotencyInFlight:
+		if stored.Fingerprint != fingerprint {
+			i.reuseError(ctx)
+			return false, nil
+		}
+		ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, "1")
+		ctx.SetStatusCode(fasthttp.StatusConflict)
+		ctx.SetBodyString("Error: 409 Request Already In Progress")
+	default:
+		// something else was stored under the key, let the request through rather than block it forever
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Always records the response of the request which claimed the key, or releases the key when it failed
+func (i *Idempotency) Always(ctx *fasthttp.RequestCtx, outcome Outcome) {
+	request, ok := idempotencyContextKey.Get(ctx).(idempotencyRequest)
+	if !ok {
+		return
+	}
+
+	// a timed out handler is still running, the key stays in flight until InFlightTtl so it is not run twice
+	if outcome.TimedOut {
+		return
+	}
+	if outcome.Panic != nil || outcome.Status >= fasthttp.StatusBadRequest || ctx.Response.IsBodyStream() {
+		i.config.Cache.Delete(request.key)
+		return
+	}
+
+	var cookies []string
+	ctx.Response.Header.VisitAllCookie(func(name, value []byte) {
+		cookies = append(cookies, string(value))
+	})
+
+	i.config.Cache.Set(request.key, &idempotentResponse{
+		Fingerprint: request.fingerprint,
+		Response: &CachedResponse{
+			Status:  outcome.Status,
+			Headers: getCachedHeaders(ctx),
+			Body:    append([]byte(nil), ctx.Response.Body()...),
+			Created: time.Now(),
+		},
+		Cookies: cookies,
+	}, i.config.Ttl)
+}
+
+func setIdempotentCookies(ctx *fasthttp.RequestCtx, cookies []string) {
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	for _, value := range cookies {
+		if cookie.Parse(value) == nil {
+			ctx.Response.Header.SetCookie(cookie)
+		}
+		cookie.Reset()
+	}
+}
+
+// NewField returns a hidden form field with a fresh key, render it with the form so a double submit sends the same key
+func (i *Idempotency) NewField() cbform.Field {
+	return cbform.Field{
+		Name:  i.config.FieldName,
+		Type:  "hidden",
+		Value: NewIdempotencyKey(),
+	}
+}
+
+func (i *Idempotency) reuseError(ctx *fasthttp.RequestCtx) {
+	ctx.SetStatusCode(fasthttp.StatusUnprocessableEntity)
+	ctx.SetBodyString("Error: 422 " + i.config.HeaderName + " Reused With A Different Request")
+}
+
+func (i *Idempotency) getSubmittedKey(ctx *fasthttp.RequestCtx) string {
+	if header := ctx.Request.Header.Peek(i.config.HeaderName); len(header) > 0 {
+		return string(header)
+	}
+	if field := ctx.PostArgs().Peek(i.config.FieldName); len(field) > 0 {
+		return string(field)
+	}
+	if form, e := ctx.MultipartForm(); e == nil {
+		if values := form.Value[i.config.FieldName]; len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+func (i *Idempotency) getKey(ctx *fasthttp.RequestCtx, submitted string) string {
+	identifier := ""
+	if i.config.Auth != nil {
+		identifier = i.config.Auth.FindUniqueIdentifier(ctx)
+	}
+
+	hash := sha256.Sum256([]byte(identifier + "\n" + string(ctx.Method()) + "\n" + GetMountPrefix(ctx) + string(ctx.Path()) + "\n" + submitted))
+
+	return i.config.KeyPrefix + hex.EncodeToString(hash[:])
+}
+
+// getFingerprint hashes what was submitted, multipart forms are hashed by their values because
+// browsers pick a new boundary for every submit
+func (i *Idempotency) getFingerprint(ctx *fasthttp.RequestCtx) string {
+	hash := sha256.New()
+	if form, e := ctx.MultipartForm(); e == nil {
+		var fields []string
+		for name, values := range form.Value {
+			fields = append(fields, name+"="+strings.Join(values, "\x00"))
+		}
+		for name, files := range form.File {
+			for _, file := range files {
+				fields = append(fields, name+"@"+file.Filename+":"+strconv.FormatInt(file.Size, 10))
+			}
+		}
+		sort.Strings(fields)
+		_, _ = hash.Write([]byte(strings.Join(fields, "\n")))
+	} else {
+		_, _ = hash.Write(ctx.Request.Header.ContentType())
+		_, _ = hash.Write([]byte("\n"))
+		_, _ = hash.Write(ctx.Request.Body())
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func NewIdempotencyKey() string {
+	key := make([]byte, 16)
+	_, e := rand.Read(key)
+	if e != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(key)
+}
@@ -0,0 +1,108 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"testing"
+)
+
+type idempotencyModule struct {
+	idempotency *cbweb.Idempotency
+	calls       int
+}
+
+func (m *idempotencyModule) SetRoutes(r *router.Router) {
+	r.POST("/orders", cbweb.MiddlewareHandler{}.
+		AddMiddleware(m.idempotency.Middleware).
+		AddAlways(m.idempotency.Always).
+		SetFinal(func(ctx *fasthttp.RequestCtx) {
+			m.calls++
+			ctx.SetStatusCode(fasthttp.StatusCreated)
+			ctx.SetContentType("application/json")
+			ctx.Response.Header.Set("Location", "/orders/1")
+			ctx.SetBodyString(`{"id":1}`)
+		}).
+		Handle,
+	)
+}
+
+func (m *idempotencyModule) GetGlobalTemplates() map[string][]byte {
+	return nil
+}
+
+func (m *idempotencyModule) SetGlobalTemplates(templates map[string][]byte) {}
+
+func TestIdempotencyReplay(t *testing.T) {
+	module := &idempotencyModule{
+		idempotency: cbweb.NewIdempotency(cbweb.IdempotencyConfig{Cache: cbwebtest.NewCache()}),
+	}
+	h := cbwebtest.New(t, cbweb.Dependencies{}, module)
+	h.FollowRedirects = false
+	headers := map[string]string{"Idempotency-Key": "order-1", "Content-Type": "application/json"}
+
+	h.Do(fasthttp.MethodPost, "/orders", []byte(`{"item":"a"}`), headers).
+		AssertStatus(fasthttp.StatusCreated).
+		AssertHeader("Content-Type", "application/json").
+		AssertNoHeader(cbweb.IdempotencyReplayedHeader)
+
+	h.Do(fasthttp.MethodPost, "/orders", []byte(`{"item":"a"}`), headers).
+		AssertStatus(fasthttp.StatusCreated).
+		AssertHeader("Content-Type", "application/json").
+		AssertHeader("Location", "/orders/1").
+		AssertHeader(cbweb.IdempotencyReplayedHeader, "true").
+		AssertBodyContains(`{"id":1}`)
+
+	if module.calls != 1 {
+		t.Errorf("expected the handler to run once, it ran %d times", module.calls)
+	}
+
+	h.Do(fasthttp.MethodPost, "/orders", []byte(`{"item":"b"}`), headers).
+		AssertStatus(fasthttp.StatusUnprocessableEntity)
+}
+
+func TestIdempotencyReplaysCookies(t *testing.T) {
+	flash, e := cbweb.NewFlashStore(cbweb.FlashStoreConfig{Secret: "secret"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	idempotency := cbweb.NewIdempotency(cbweb.IdempotencyConfig{Cache: cbwebtest.NewCache()})
+	calls := 0
+
+	h := cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.POST("/save", cbweb.MiddlewareHandler{}.
+			AddMiddleware(flash.Middleware, idempotency.Middleware).
+			AddAlways(flash.Always, idempotency.Always).
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				calls++
+				cbweb.GetFlash(ctx).AddMessage("default", cbweb.FlashMessage{Type: "green", Message: "Saved"})
+				ctx.Redirect("/show", fasthttp.StatusFound)
+			}).
+			Handle,
+		)
+		r.GET("/show", cbweb.MiddlewareHandler{}.
+			AddMiddleware(flash.Middleware).
+			AddAlways(flash.Always).
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				for _, message := range cbweb.GetFlash(ctx).GetMessages("default") {
+					ctx.WriteString(message.Type + ": " + message.Message)
+				}
+			}).
+			Handle,
+		)
+	}})
+	headers := map[string]string{"Idempotency-Key": "save-1"}
+
+	h.Do(fasthttp.MethodPost, "/save", nil, headers).AssertRedirectedTo("/show").AssertBodyContains("green: Saved")
+
+	// the first response was lost, so the browser never got the flash cookie and submits again
+	h.ClearCookies()
+	h.Do(fasthttp.MethodPost, "/save", nil, headers).
+		AssertRedirectedTo("/show").
+		AssertBodyContains("green: Saved")
+
+	if calls != 1 {
+		t.Errorf("expected the handler to run once, it ran %d times", calls)
+	}
+}
@@ -0,0 +1,199 @@
+package cbweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ModuleNamer interface {
+	GetModuleName() string
+}
+
+type ModuleDependent interface {
+	GetModuleDependencies() []string
+}
+
+type ModuleInitialiser interface {
+	Init(server *Server) error
+}
+
+type ModuleStarter interface {
+	Start() error
+}
+
+type ModuleStopper interface {
+	Stop(ctx context.Context) error
+}
+
+type ModuleHealthChecker interface {
+	HealthCheck() error
+}
+
+// GetModuleName defaults to the module's type, two modules of the same type need ModuleNamer to tell them apart
+func GetModuleName(module Module) string {
+	if namer, ok := module.(ModuleNamer); ok {
+		return namer.GetModuleName()
+	}
+
+	return fmt.Sprintf("%T", module)
+}
+
+// orderModules returns the keys of the modules ordered so every module comes after the modules it depends on,
+// otherwise keeping the order they were added to the server. Names must be unique as dependencies and health checks use them
+func orderModules(modules []Module) ([]int, error) {
+	byName := make(map[string]int, len(modules))
+	for key, module := range modules {
+		name := GetModuleName(module)
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("two modules are named %s, implement ModuleNamer to give them different names", name)
+		}
+		byName[name] = key
+	}
+
+	dependents := make(map[int][]int)
+	remaining := make(map[int]int, len(modules))
+	for key, module := range modules {
+		dependent, ok := module.(ModuleDependent)
+		if !ok {
+			continue
+		}
+		for _, dependency := range dependent.GetModuleDependencies() {
+			dependencyKey, ok := byName[dependency]
+			if !ok {
+				return nil, fmt.Errorf("module %s depends on missing module %s", GetModuleName(module), dependency)
+			}
+			dependents[dependencyKey] = append(dependents[dependencyKey], key)
+			remaining[key]++
+		}
+	}
+
+	var ready []int
+	for key := range modules {
+		if remaining[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	var ordered []int
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, key)
+		for _, dependentKey := range dependents[key] {
+			remaining[dependentKey]--
+			if remaining[dependentKey] == 0 {
+				ready = append(ready, dependentKey)
+				sort.Ints(ready)
+			}
+		}
+	}
+
+	if len(ordered) != len(modules) {
+		var cyclic []string
+		for key, module := range modules {
+			if remaining[key] > 0 {
+				cyclic = append(cyclic, GetModuleName(module))
+			}
+		}
+		return nil, errors.New("module dependency cycle between " + strings.Join(cyclic, ", "))
+	}
+
+	return ordered, nil
+}
+
+// initModules stops the modules which were already initialised when one fails
+func (s *Server) initModules(modules []Module) error {
+	for key, module := range modules {
+		if initialiser, ok := module.(ModuleInitialiser); ok {
+			e := initialiser.Init(s)
+			if e != nil {
+				s.stopInitialisedModules(modules[:key])
+				return fmt.Errorf("module %s init: %w", GetModuleName(module), e)
+			}
+		}
+	}
+
+	return nil
+}
+
+// startModules leaves the initialised modules which were not started with the started ones when one fails,
+// so stopModules stops both
+func (s *Server) startModules(modules []Module) error {
+	for key, module := range modules {
+		if starter, ok := module.(ModuleStarter); ok {
+			e := starter.Start()
+			if e != nil {
+				s.startedModulesLock.Lock()
+				s.startedModules = append(s.startedModules, getInitialisedModules(modules[key:])...)
+				s.startedModulesLock.Unlock()
+				return fmt.Errorf("module %s start: %w", GetModuleName(module), e)
+			}
+		}
+		s.startedModulesLock.Lock()
+		s.startedModules = append(s.startedModules, module)
+		s.startedModulesLock.Unlock()
+	}
+
+	return nil
+}
+
+// stopInitialisedModules stops the modules which were initialised when the server fails before starting them
+func (s *Server) stopInitialisedModules(modules []Module) {
+	s.startedModulesLock.Lock()
+	s.startedModules = append(s.startedModules, getInitialisedModules(modules)...)
+	s.startedModulesLock.Unlock()
+
+	s.stopModulesAfterFailedStart()
+}
+
+func getInitialisedModules(modules []Module) []Module {
+	var initialised []Module
+	for _, module := range modules {
+		if _, ok := module.(ModuleInitialiser); ok {
+			initialised = append(initialised, module)
+		}
+	}
+
+	return initialised
+}
+
+// stopModules stops the started modules in the reverse of the order they were started in
+func (s *Server) stopModules(ctx context.Context) error {
+	s.startedModulesLock.Lock()
+	started := s.startedModules
+	s.startedModules = nil
+	s.startedModulesLock.Unlock()
+
+	var stopError error
+	for i := len(started) - 1; i >= 0; i-- {
+		stopper, ok := started[i].(ModuleStopper)
+		if !ok {
+			continue
+		}
+		e := stopper.Stop(ctx)
+		if e != nil {
+			e = fmt.Errorf("module %s stop: %w", GetModuleName(started[i]), e)
+			if stopError == nil {
+				stopError = e
+			} else {
+				s.errorHandler.Error(e)
+			}
+		}
+	}
+
+	return stopError
+}
+
+func (s *Server) HealthCheck() map[string]error {
+	health := make(map[string]error)
+	for _, module := range s.modules {
+		if checker, ok := module.(ModuleHealthChecker); ok {
+			health[GetModuleName(module)] = checker.HealthCheck()
+		}
+	}
+
+	return health
+}
@@ -0,0 +1,102 @@
+package cbweb_test
+
+import (
+	"context"
+	"errors"
+	"github.com/codingbeard/cbweb"
+	"github.com/fasthttp/router"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lifecycleModule records its Init, Start and Stop calls in events
+type lifecycleModule struct {
+	routesModule
+	name       string
+	initError  error
+	startError error
+	events     *[]string
+}
+
+func (m *lifecycleModule) GetModuleName() string {
+	return m.name
+}
+
+func (m *lifecycleModule) Init(server *cbweb.Server) error {
+	*m.events = append(*m.events, "init "+m.name)
+	return m.initError
+}
+
+func (m *lifecycleModule) Start() error {
+	*m.events = append(*m.events, "start "+m.name)
+	return m.startError
+}
+
+func (m *lifecycleModule) Stop(ctx context.Context) error {
+	*m.events = append(*m.events, "stop "+m.name)
+	return nil
+}
+
+func TestModuleNamesAreUnique(t *testing.T) {
+	noRoutes := func(r *router.Router) {}
+	server := cbweb.NewServer(cbweb.Dependencies{Port: "127.0.0.1:0"}, &routesModule{routes: noRoutes}, &routesModule{routes: noRoutes})
+
+	e := server.Start()
+	if e == nil || !strings.Contains(e.Error(), "two modules are named") {
+		t.Fatalf("expected an error for two modules with the same name, got %v", e)
+	}
+}
+
+func TestModulesStoppedAfterFailedStart(t *testing.T) {
+	failure := errors.New("failed")
+	tests := []struct {
+		name         string
+		dependencies cbweb.Dependencies
+		initError    string
+		startError   string
+		expected     []string
+	}{
+		{
+			name:         "init",
+			dependencies: cbweb.Dependencies{Port: "127.0.0.1:0"},
+			initError:    "c",
+			expected:     []string{"init a", "init b", "init c", "stop b", "stop a"},
+		},
+		{
+			name:         "listeners",
+			dependencies: cbweb.Dependencies{},
+			expected:     []string{"init a", "init b", "init c", "stop c", "stop b", "stop a"},
+		},
+		{
+			name:         "start",
+			dependencies: cbweb.Dependencies{Port: "127.0.0.1:0"},
+			startError:   "b",
+			expected:     []string{"init a", "init b", "init c", "start a", "start b", "stop c", "stop b", "stop a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var events []string
+			server := cbweb.NewServer(test.dependencies)
+			for _, name := range []string{"a", "b", "c"} {
+				module := &lifecycleModule{routesModule: routesModule{routes: func(r *router.Router) {}}, name: name, events: &events}
+				if name == test.initError {
+					module.initError = failure
+				}
+				if name == test.startError {
+					module.startError = failure
+				}
+				server.AddModule(module)
+			}
+
+			if e := server.Start(); e == nil {
+				t.Fatal("expected Start to fail")
+			}
+			if !reflect.DeepEqual(events, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, events)
+			}
+		})
+	}
+}
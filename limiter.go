@@ -3,16 +3,30 @@ package cbweb
 import (
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/config"
+	"github.com/valyala/fasthttp"
+	"net"
+	"strings"
+	"sync"
 	"time"
 )
 
+var (
+	// limiterProxies holds the TrustedProxies of limiters built by NewLimiter, HandleLimited uses them for the ip
+	limiterProxies sync.Map
+	// forwardedForProxies trusts the connection as a single proxy, believing only the hop it appended
+	forwardedForProxies = &TrustedProxies{peer: true}
+)
+
 type LimiterConfig struct {
-	Max int64
-	Ttl time.Duration
-	Methods []string
+	Max         int64
+	Ttl         time.Duration
+	Methods     []string
 	ContentType string
-	Message string
+	Message     string
+	// ForwardedForIp believes the last X-Forwarded-For hop of every connection, only set it when the server
+	// can only be reached through one proxy. TrustedProxies is used instead when it is set
 	ForwardedForIp bool
+	TrustedProxies *TrustedProxies
 }
 
 func NewLimiter(config LimiterConfig) *config.Limiter {
@@ -22,19 +36,98 @@ func NewLimiter(config LimiterConfig) *config.Limiter {
 	if config.Ttl == 0 {
 		config.Ttl = time.Second
 	}
-	if config.ContentType == "" {
-		config.ContentType = "text/plain"
-	}
 
 	limiter := tollbooth.NewLimiterExpiringBuckets(config.Max, config.Ttl, time.Hour, time.Second)
 	if len(config.Methods) != 0 {
 		limiter.Methods = config.Methods
 	}
+	// an empty content type and message let HandleLimited match the route and use its default message
 	limiter.MessageContentType = config.ContentType
 	limiter.Message = config.Message
-	if config.ForwardedForIp {
-		limiter.IPLookups = []string{"X-Forwarded-For", "RemoteAddr"}
+	// tollbooth would believe the left-most X-Forwarded-For, HandleLimited swaps in the ip from GetRemoteIp
+	limiter.IPLookups = []string{"RemoteAddr"}
+	if config.TrustedProxies != nil {
+		limiterProxies.Store(limiter, config.TrustedProxies)
+	} else if config.ForwardedForIp {
+		limiterProxies.Store(limiter, forwardedForProxies)
 	}
 
 	return limiter
-}
\ No newline at end of file
+}
+
+func getLimiterProxies(limiter *config.Limiter) *TrustedProxies {
+	proxies, _ := limiterProxies.Load(limiter)
+	trusted, _ := proxies.(*TrustedProxies)
+
+	return trusted
+}
+
+// TrustedProxies are the proxies whose X-Forwarded-For is believed. Build it once and give it to the access log,
+// rate limiters and cbwebauth.BruteForceConfig.GetIp so they all agree on the client's ip
+type TrustedProxies struct {
+	networks []*net.IPNet
+	// peer trusts whatever the connection comes from, but none of the hops it forwarded
+	peer bool
+}
+
+// NewTrustedProxies parses ips and cidr ranges, an unparsable proxy is not trusted
+func NewTrustedProxies(proxies ...string) *TrustedProxies {
+	trusted := &TrustedProxies{}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		if _, network, e := net.ParseCIDR(proxy); e == nil {
+			trusted.networks = append(trusted.networks, network)
+		}
+	}
+
+	return trusted
+}
+
+func (p *TrustedProxies) IsTrusted(ip net.IP) bool {
+	for _, network := range p.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRemoteIp is GetRemoteIp with these proxies, it can be passed as cbwebauth.BruteForceConfig.GetIp
+func (p *TrustedProxies) GetRemoteIp(ctx *fasthttp.RequestCtx) string {
+	return GetRemoteIp(ctx, p)
+}
+
+// GetRemoteIp returns the client ip. X-Forwarded-For is only believed when the connection comes from one of the
+// proxies, the client is then the right-most hop which is not a proxy as anything left of it can be forged.
+// Without proxies the connection's ip is returned
+func GetRemoteIp(ctx *fasthttp.RequestCtx, proxies *TrustedProxies) string {
+	remoteIp := ctx.RemoteIP()
+	if proxies == nil || (!proxies.peer && !proxies.IsTrusted(remoteIp)) {
+		return remoteIp.String()
+	}
+
+	var hops []string
+	for _, header := range ctx.Request.Header.PeekAll(fasthttp.HeaderXForwardedFor) {
+		hops = append(hops, strings.Split(string(header), ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// a hop which is not an ip was not written by a trusted proxy, so nothing left of it can be trusted
+			break
+		}
+		if proxies.peer || !proxies.IsTrusted(ip) {
+			return ip.String()
+		}
+		remoteIp = ip
+	}
+
+	return remoteIp.String()
+}
@@ -0,0 +1,44 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/valyala/fasthttp"
+	"net"
+	"testing"
+)
+
+func TestGetRemoteIp(t *testing.T) {
+	proxies := cbweb.NewTrustedProxies("10.0.0.0/8", "192.168.1.1")
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		proxies   *cbweb.TrustedProxies
+		expected  string
+	}{
+		{name: "no proxies ignores the header", remote: "10.0.0.1", forwarded: []string{"1.1.1.1"}, expected: "10.0.0.1"},
+		{name: "untrusted connection ignores the header", remote: "8.8.8.8", forwarded: []string{"1.1.1.1"}, proxies: proxies, expected: "8.8.8.8"},
+		{name: "trusted connection uses the hop it appended", remote: "10.0.0.1", forwarded: []string{"1.1.1.1"}, proxies: proxies, expected: "1.1.1.1"},
+		{name: "forged hops left of the client are ignored", remote: "10.0.0.1", forwarded: []string{"6.6.6.6, 1.1.1.1"}, proxies: proxies, expected: "1.1.1.1"},
+		{name: "trusted hops are skipped", remote: "10.0.0.1", forwarded: []string{"1.1.1.1, 192.168.1.1", "10.0.0.2"}, proxies: proxies, expected: "1.1.1.1"},
+		{name: "a hop which is not an ip stops the walk", remote: "10.0.0.1", forwarded: []string{"1.1.1.1, junk, 10.0.0.2"}, proxies: proxies, expected: "10.0.0.2"},
+		{name: "only proxies falls back to the left-most proxy", remote: "10.0.0.1", forwarded: []string{"10.0.0.3"}, proxies: proxies, expected: "10.0.0.3"},
+		{name: "no header uses the connection", remote: "10.0.0.1", proxies: proxies, expected: "10.0.0.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var req fasthttp.Request
+			for _, forwarded := range test.forwarded {
+				req.Header.Add(fasthttp.HeaderXForwardedFor, forwarded)
+			}
+			var ctx fasthttp.RequestCtx
+			ctx.Init(&req, &net.TCPAddr{IP: net.ParseIP(test.remote), Port: 1234}, nil)
+
+			if ip := cbweb.GetRemoteIp(&ctx, test.proxies); ip != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, ip)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package cbweb
+
+import (
+	"errors"
+	"fmt"
+	"github.com/valyala/fasthttp"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RequestLimits bound the size and duration of a request, they are applied per route or group with
+// MiddlewareHandler.SetLimits, or before the body is read with Dependencies.RequestLimitRules
+type RequestLimits struct {
+	MaxRequestBodySize int
+	// ReadTimeout and WriteTimeout are connection deadlines, so they only apply through RequestLimitRules
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// HandlerTimeout answers 503 when the handler has not finished in time, the handler keeps running
+	// in the background and its response is discarded
+	HandlerTimeout time.Duration
+	// TimeoutPage renders the 503 response, pass a module's FiveHundredError style page to keep the site's look
+	TimeoutPage func(ctx *fasthttp.RequestCtx)
+	// TooLargePage renders the 413 response when the body is larger than MaxRequestBodySize
+	TooLargePage func(ctx *fasthttp.RequestCtx)
+}
+
+// RequestLimitRule applies Limits to requests whose path starts with PathPrefix, the longest prefix wins.
+// As the rules are matched when the headers arrive, MaxRequestBodySize may be larger than
+// Dependencies.MaxRequestBodySize, so upload routes can accept big bodies while everything else stays small
+type RequestLimitRule struct {
+	Method     string
+	PathPrefix string
+	Limits     RequestLimits
+}
+
+func (m MiddlewareHandler) SetLimits(limits RequestLimits) MiddlewareHandler {
+	m.limits = &limits
+
+	return m
+}
+
+// Middleware rejects requests with a body larger than MaxRequestBodySize,
+// the body has already been read so the server wide limit still bounds memory use
+func (l RequestLimits) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if l.MaxRequestBodySize <= 0 || len(ctx.Request.Body()) <= l.MaxRequestBodySize {
+		return true, nil
+	}
+
+	ctx.SetStatusCode(fasthttp.StatusRequestEntityTooLarge)
+	ctx.Response.Header.Set(fasthttp.HeaderConnection, "close")
+	if l.TooLargePage != nil {
+		l.TooLargePage(ctx)
+		ctx.SetStatusCode(fasthttp.StatusRequestEntityTooLarge)
+	} else {
+		ctx.SetBodyString("Error: 413 Request Entity Too Large")
+	}
+
+	return false, nil
+}
+
+// Timeout runs handler with the HandlerTimeout deadline, errorHandler is given panics from the handler
+// as they happen outside of the server's recover
+func (l RequestLimits) Timeout(ctx *fasthttp.RequestCtx, errorHandler ErrorHandler, handler fasthttp.RequestHandler) {
+	l.timeout(ctx, errorHandler, handler, nil)
+}
+
+// timeout calls onTimeout with the timeout page before it is sent, on the server's goroutine
+func (l RequestLimits) timeout(ctx *fasthttp.RequestCtx, errorHandler ErrorHandler, handler fasthttp.RequestHandler, onTimeout func(page *fasthttp.RequestCtx)) {
+	if l.HandlerTimeout <= 0 {
+		handler(ctx)
+		return
+	}
+
+	state := &handlerTimeout{parent: getHandlerTimeout(ctx)}
+	handlerTimeoutContextKey.Set(ctx, state)
+
+	// the page is rendered on its own ctx as the handler may still be using this one
+	page := &fasthttp.RequestCtx{}
+	ctx.Request.Header.CopyTo(&page.Request.Header)
+	ctx.VisitUserValuesAll(func(key, value interface{}) {
+		page.SetUserValue(key, value)
+	})
+	if requestId := GetRequestId(page); requestId != "" {
+		page.Response.Header.Set(RequestIdHeader, requestId)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			rec := recover()
+			if rec != nil {
+				if e, ok := rec.(error); ok {
+					HandleError(errorHandler, ctx, e)
+				} else {
+					HandleError(errorHandler, ctx, errors.New(fmt.Sprint(rec)))
+				}
+				if metrics := GetMetrics(ctx); metrics != nil {
+					metrics.IncrementCounter(MetricPanicsRecovered, nil, 1)
+				}
+				if !state.timedOut() {
+					ctx.Response.SetStatusCode(fasthttp.StatusInternalServerError)
+				}
+			}
+		}()
+
+		handler(ctx)
+	}()
+
+	timer := time.NewTimer(l.HandlerTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		if !atomic.CompareAndSwapInt32(&state.state, handlerTimeout_Running, handlerTimeout_TimedOut) {
+			// the handler claimed the ctx for its always phase just before the deadline, let it finish
+			<-done
+			return
+		}
+		if l.TimeoutPage != nil {
+			l.TimeoutPage(page)
+		} else {
+			page.SetBodyString("Error: 503 Service Unavailable")
+		}
+		page.SetStatusCode(fasthttp.StatusServiceUnavailable)
+		page.Response.Header.Set(fasthttp.HeaderRetryAfter, strconv.Itoa(int(l.HandlerTimeout/time.Second)+1))
+		if onTimeout != nil {
+			onTimeout(page)
+		}
+		ctx.TimeoutErrorWithResponse(&page.Response)
+	}
+}
+
+// handlerTimeout is owned by one Timeout call. The server's goroutine writes the ctx's timeout response,
+// so the handler's goroutine reads this instead
+type handlerTimeout struct {
+	state  int32
+	parent *handlerTimeout
+}
+
+func getHandlerTimeout(ctx *fasthttp.RequestCtx) *handlerTimeout {
+	state, _ := handlerTimeoutContextKey.Get(ctx).(*handlerTimeout)
+
+	return state
+}
+
+func (h *handlerTimeout) timedOut() bool {
+	for ; h != nil; h = h.parent {
+		if atomic.LoadInt32(&h.state) == handlerTimeout_TimedOut {
+			return true
+		}
+	}
+
+	return false
+}
+
+// claim stops the timeout firing so the handler's goroutine can keep using the ctx, it returns false
+// when the timeout already fired and the ctx must be left alone
+func (h *handlerTimeout) claim() bool {
+	if h == nil {
+		return true
+	}
+	if h.parent.timedOut() {
+		return false
+	}
+
+	return atomic.CompareAndSwapInt32(&h.state, handlerTimeout_Running, handlerTimeout_Claimed) ||
+		atomic.LoadInt32(&h.state) == handlerTimeout_Claimed
+}
+
+const (
+	handlerTimeout_Running int32 = iota
+	handlerTimeout_TimedOut
+	// handlerTimeout_Claimed is set once the handler reached its always phase, the timeout then waits for it
+	handlerTimeout_Claimed
+)
+
+var handlerTimeoutContextKey = NewContextKey("handler-timeout")
+
+type requestLimitRules []RequestLimitRule
+
+func newRequestLimitRules(rules []RequestLimitRule) requestLimitRules {
+	sorted := append(requestLimitRules(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].PathPrefix) > len(sorted[j].PathPrefix)
+	})
+
+	return sorted
+}
+
+func (r requestLimitRules) find(method, path []byte) (RequestLimits, bool) {
+	for _, rule := range r {
+		if rule.Method != "" && !strings.EqualFold(rule.Method, string(method)) {
+			continue
+		}
+		if strings.HasPrefix(string(path), rule.PathPrefix) {
+			return rule.Limits, true
+		}
+	}
+
+	return RequestLimits{}, false
+}
+
+func (r requestLimitRules) headerReceived(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+	// matched against the path as the router sees it, without the query string and with // and .. resolved
+	uri := fasthttp.AcquireURI()
+	defer fasthttp.ReleaseURI(uri)
+	_ = uri.Parse(header.Host(), header.RequestURI())
+
+	limits, ok := r.find(header.Method(), uri.Path())
+	if !ok {
+		return fasthttp.RequestConfig{}
+	}
+
+	return fasthttp.RequestConfig{
+		ReadTimeout:        limits.ReadTimeout,
+		WriteTimeout:       limits.WriteTimeout,
+		MaxRequestBodySize: limits.MaxRequestBodySize,
+	}
+}
+
+// readErrorHandler answers requests fasthttp failed to read, reporting bodies over the limit as 413 instead of 400
+func readErrorHandler(ctx *fasthttp.RequestCtx, e error) {
+	if _, ok := e.(*fasthttp.ErrSmallBuffer); ok {
+		ctx.Error("Too big request header", fasthttp.StatusRequestHeaderFieldsTooLarge)
+	} else if netError, ok := e.(*net.OpError); ok && netError.Timeout() {
+		ctx.Error("Request timeout", fasthttp.StatusRequestTimeout)
+	} else if e == fasthttp.ErrBodyTooLarge {
+		ctx.Error("Error: 413 Request Entity Too Large", fasthttp.StatusRequestEntityTooLarge)
+	} else {
+		ctx.Error("Error when parsing request", fasthttp.StatusBadRequest)
+	}
+}
+
+// IsTimedOut reports whether a HandlerTimeout fired. It reads the ctx's timeout response, so it is only safe on
+// the server's goroutine once the handler returned, such as in a handler wrapping the router. Within a
+// MiddlewareHandler chain use Outcome.TimedOut
+func IsTimedOut(ctx *fasthttp.RequestCtx) bool {
+	return ctx.LastTimeoutErrorResponse() != nil
+}
+
+// isHandlerTimedOut is IsTimedOut for the handler's own goroutine
+func isHandlerTimedOut(ctx *fasthttp.RequestCtx) bool {
+	return getHandlerTimeout(ctx).timedOut()
+}
+
+// getResponse returns the response which will be sent, which is the timeout page once a handler timed out
+func getResponse(ctx *fasthttp.RequestCtx) *fasthttp.Response {
+	if response := ctx.LastTimeoutErrorResponse(); response != nil {
+		return response
+	}
+
+	return &ctx.Response
+}
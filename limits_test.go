@@ -0,0 +1,73 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerTimeout(t *testing.T) {
+	lateWritten := make(chan struct{}, 2)
+	slow := func(ctx *fasthttp.RequestCtx) {
+		time.Sleep(time.Millisecond * 200)
+		ctx.Response.Header.Set("X-Late", "true")
+		ctx.SetBodyString("late")
+		lateWritten <- struct{}{}
+	}
+	timeoutPage := func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("text/html; charset=utf-8")
+		ctx.SetBodyString("<h1>Taking too long</h1>")
+	}
+	var outcomes []cbweb.Outcome
+
+	h := cbwebtest.New(t, cbweb.Dependencies{
+		RequestLimitRules: []cbweb.RequestLimitRule{{
+			PathPrefix: "/rule",
+			Limits:     cbweb.RequestLimits{HandlerTimeout: time.Millisecond * 50},
+		}},
+	}, &routesModule{routes: func(r *router.Router) {
+		r.GET("/slow", cbweb.MiddlewareHandler{}.
+			SetLimits(cbweb.RequestLimits{HandlerTimeout: time.Millisecond * 50, TimeoutPage: timeoutPage}).
+			AddAlways(func(ctx *fasthttp.RequestCtx, outcome cbweb.Outcome) {
+				outcomes = append(outcomes, outcome)
+			}).
+			SetFinal(slow).
+			Handle,
+		)
+		r.GET("/rule/slow", slow)
+		r.GET("/fast", func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("fast")
+		})
+	}})
+
+	tests := map[string]string{
+		"/slow":      "<h1>Taking too long</h1>",
+		"/rule/slow": "Error: 503 Service Unavailable",
+	}
+	for path, body := range tests {
+		t.Run(strings.TrimPrefix(path, "/"), func(t *testing.T) {
+			start := time.Now()
+			h.Get(path).
+				AssertStatus(fasthttp.StatusServiceUnavailable).
+				AssertHeader(fasthttp.HeaderRetryAfter, "1").
+				AssertNoHeader("X-Late").
+				AssertBodyContains(body).
+				AssertBodyNotContains("late")
+			if elapsed := time.Since(start); elapsed >= time.Millisecond*200 {
+				t.Errorf("expected the timeout page before the handler finished, it took %s", elapsed)
+			}
+
+			// the handler's late write goes nowhere, the next request gets its own response
+			<-lateWritten
+			h.Get("/fast").AssertStatus(fasthttp.StatusOK).AssertNoHeader("X-Late").AssertBodyContains("fast")
+		})
+	}
+
+	if len(outcomes) != 1 || !outcomes[0].TimedOut || outcomes[0].Status != fasthttp.StatusServiceUnavailable {
+		t.Errorf("expected the always phase to see the timeout, got %+v", outcomes)
+	}
+}
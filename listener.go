@@ -0,0 +1,221 @@
+package cbweb
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"sync"
+)
+
+type Listener struct {
+	Name string
+	// Network is passed to net.Listen, it defaults to tcp4 and can be set to unix for a unix socket
+	Network  string
+	Address  string
+	CertFile string
+	KeyFile  string
+	// Modules limits the listener to a subset of the server's modules, when empty it serves all of them
+	Modules []Module
+	// NetListener is served instead of listening on Network and Address when it is set
+	NetListener net.Listener
+}
+
+func (l Listener) getName() string {
+	if l.Name != "" {
+		return l.Name
+	}
+	if l.Network == "unix" {
+		return "unix:" + l.Address
+	}
+	if l.Address == "" && l.NetListener != nil {
+		return l.NetListener.Addr().String()
+	}
+
+	return l.Address
+}
+
+func (l Listener) isTLS() bool {
+	return l.CertFile != "" || l.KeyFile != ""
+}
+
+func (l Listener) serves(module Module) bool {
+	if len(l.Modules) == 0 {
+		return true
+	}
+	for _, candidate := range l.Modules {
+		if sameModule(candidate, module) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameModule(a, b Module) bool {
+	typeA, typeB := reflect.TypeOf(a), reflect.TypeOf(b)
+	if typeA != typeB || !typeA.Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+type certificateReloader struct {
+	certFile    string
+	keyFile     string
+	certificate *tls.Certificate
+	lock        sync.RWMutex
+}
+
+func newCertificateReloader(certFile, keyFile string) (*certificateReloader, error) {
+	reloader := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	return reloader, reloader.reload()
+}
+
+func (c *certificateReloader) reload() error {
+	certificate, e := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if e != nil {
+		return fmt.Errorf("loading certificate %s: %w", c.certFile, e)
+	}
+
+	c.lock.Lock()
+	c.certificate = &certificate
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.certificate, nil
+}
+
+func (s *Server) getListeners() ([]Listener, error) {
+	var listeners []Listener
+	if s.port != "" {
+		listeners = append(listeners, Listener{
+			Address:  s.port,
+			CertFile: s.tlsCertFile,
+			KeyFile:  s.tlsKeyFile,
+		})
+	}
+	listeners = append(listeners, s.listeners...)
+
+	if len(listeners) == 0 {
+		return nil, errors.New("no port or listeners configured")
+	}
+
+	names := make(map[string]bool)
+	for _, listener := range listeners {
+		if names[listener.getName()] {
+			return nil, fmt.Errorf("listener %s is configured twice", listener.getName())
+		}
+		names[listener.getName()] = true
+
+		if listener.isTLS() && (listener.CertFile == "" || listener.KeyFile == "") {
+			return nil, fmt.Errorf("listener %s needs both a CertFile and a KeyFile", listener.getName())
+		}
+
+		for _, module := range listener.Modules {
+			registered := false
+			for _, candidate := range s.modules {
+				if sameModule(candidate, module) {
+					registered = true
+					break
+				}
+			}
+			if !registered {
+				return nil, fmt.Errorf("listener %s: module %s is not registered with the server", listener.getName(), GetModuleName(module))
+			}
+		}
+	}
+
+	return listeners, nil
+}
+
+func (s *Server) listen(listener Listener) (net.Listener, error) {
+	if listener.NetListener != nil {
+		if listener.isTLS() {
+			return s.listenTLS(listener, listener.NetListener)
+		}
+		return listener.NetListener, nil
+	}
+
+	network := listener.Network
+	if network == "" {
+		network = "tcp4"
+	}
+
+	if network == "unix" {
+		// only a socket left by a previous run is removed, a misconfigured address must not delete a file
+		stat, e := os.Lstat(listener.Address)
+		if e == nil {
+			if stat.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("unix socket address %s is an existing file which is not a socket", listener.Address)
+			}
+			e = os.Remove(listener.Address)
+		}
+		if e != nil && !os.IsNotExist(e) {
+			return nil, fmt.Errorf("removing stale unix socket %s: %w", listener.Address, e)
+		}
+	}
+
+	netListener, e := net.Listen(network, listener.Address)
+	if e != nil {
+		return nil, e
+	}
+
+	if !listener.isTLS() {
+		return netListener, nil
+	}
+
+	return s.listenTLS(listener, netListener)
+}
+
+func (s *Server) listenTLS(listener Listener, netListener net.Listener) (net.Listener, error) {
+	reloader, e := newCertificateReloader(listener.CertFile, listener.KeyFile)
+	if e != nil {
+		_ = netListener.Close()
+		return nil, e
+	}
+
+	s.certificatesLock.Lock()
+	s.certificates = append(s.certificates, reloader)
+	s.certificatesLock.Unlock()
+
+	return tls.NewListener(netListener, &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}), nil
+}
+
+// ReloadCertificates reloads the certificate and key files of every TLS listener,
+// a listener keeps serving its previous certificate if its files fail to load
+func (s *Server) ReloadCertificates() error {
+	s.certificatesLock.Lock()
+	certificates := s.certificates
+	s.certificatesLock.Unlock()
+
+	var reloadError error
+	for _, certificate := range certificates {
+		e := certificate.reload()
+		if e != nil {
+			if reloadError == nil {
+				reloadError = e
+			} else {
+				s.errorHandler.Error(e)
+			}
+		}
+	}
+
+	return reloadError
+}
@@ -0,0 +1,327 @@
+package cbweb
+
+import (
+	"bufio"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	MetricHttpRequests         = "cbweb_http_requests_total"
+	MetricHttpRequestDuration  = "cbweb_http_request_duration_seconds"
+	MetricPanicsRecovered      = "cbweb_panics_recovered_total"
+	MetricRateLimited          = "cbweb_rate_limited_total"
+	MetricTemplateRenderTime   = "cbweb_template_render_duration_seconds"
+	DefaultHistogramBuckets    = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	metricsContextKey          = NewContextKey("metrics")
+	metricsUnmatchedRoute      = "unmatched"
+	metricsTimedOutRoute       = "timed_out"
+	metricsKnownRequestMethods = map[string]bool{
+		fasthttp.MethodGet:     true,
+		fasthttp.MethodHead:    true,
+		fasthttp.MethodPost:    true,
+		fasthttp.MethodPut:     true,
+		fasthttp.MethodPatch:   true,
+		fasthttp.MethodDelete:  true,
+		fasthttp.MethodConnect: true,
+		fasthttp.MethodOptions: true,
+		fasthttp.MethodTrace:   true,
+	}
+)
+
+type metricType string
+
+var (
+	metricType_Counter   metricType = "counter"
+	metricType_Histogram metricType = "histogram"
+)
+
+type metricSeries struct {
+	labels  string
+	value   float64
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+type metricFamily struct {
+	name       string
+	help       string
+	metricType metricType
+	buckets    []float64
+	series     map[string]*metricSeries
+}
+
+// Metrics holds counters and histograms and writes them in the prometheus text exposition format
+type Metrics struct {
+	families map[string]*metricFamily
+	lock     sync.Mutex
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{families: make(map[string]*metricFamily)}
+
+	m.RegisterCounter(MetricHttpRequests, "Requests handled by route pattern and status.")
+	m.RegisterHistogram(MetricHttpRequestDuration, "Request latency by route pattern.", DefaultHistogramBuckets)
+	m.RegisterCounter(MetricPanicsRecovered, "Panics recovered from request handlers.")
+	m.RegisterCounter(MetricRateLimited, "Requests rejected by a rate limiter.")
+	m.RegisterHistogram(MetricTemplateRenderTime, "Time taken to generate and execute a view model's templates.", DefaultHistogramBuckets)
+
+	return m
+}
+
+func (m *Metrics) RegisterCounter(name, help string) {
+	m.register(name, help, metricType_Counter, nil)
+}
+
+func (m *Metrics) RegisterHistogram(name, help string, buckets []float64) {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	m.register(name, help, metricType_Histogram, buckets)
+}
+
+func (m *Metrics) register(name, help string, metricType metricType, buckets []float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if family, ok := m.families[name]; ok {
+		family.help = help
+		return
+	}
+
+	m.families[name] = &metricFamily{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		buckets:    buckets,
+		series:     make(map[string]*metricSeries),
+	}
+}
+
+// getSeries returns the series for the labels, registering the family with default settings when it is unknown
+func (m *Metrics) getSeries(name string, metricType metricType, labels map[string]string) *metricSeries {
+	family, ok := m.families[name]
+	if !ok {
+		family = &metricFamily{
+			name:       name,
+			metricType: metricType,
+			series:     make(map[string]*metricSeries),
+		}
+		if metricType == metricType_Histogram {
+			family.buckets = DefaultHistogramBuckets
+		}
+		m.families[name] = family
+	}
+
+	key := formatMetricLabels(labels)
+	series, ok := family.series[key]
+	if !ok {
+		series = &metricSeries{labels: key}
+		if family.metricType == metricType_Histogram {
+			series.buckets = make([]uint64, len(family.buckets))
+		}
+		family.series[key] = series
+	}
+
+	return series
+}
+
+func (m *Metrics) IncrementCounter(name string, labels map[string]string, value float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.getSeries(name, metricType_Counter, labels).value += value
+}
+
+func (m *Metrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	series := m.getSeries(name, metricType_Histogram, labels)
+	for key, bucket := range m.families[name].buckets {
+		if value <= bucket {
+			series.buckets[key]++
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+func (m *Metrics) WritePrometheus(writer io.Writer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var names []string
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buffered := bufio.NewWriter(writer)
+	for _, name := range names {
+		family := m.families[name]
+		if family.help != "" {
+			_, _ = buffered.WriteString("# HELP " + name + " " + escapeMetricHelp(family.help) + "\n")
+		}
+		_, _ = buffered.WriteString("# TYPE " + name + " " + string(family.metricType) + "\n")
+
+		var keys []string
+		for key := range family.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			series := family.series[key]
+			if family.metricType == metricType_Counter {
+				_, _ = buffered.WriteString(name + wrapMetricLabels(series.labels) + " " + formatMetricValue(series.value) + "\n")
+				continue
+			}
+
+			for bucketKey, bucket := range family.buckets {
+				labels := joinMetricLabels(series.labels, `le="`+formatMetricValue(bucket)+`"`)
+				_, _ = buffered.WriteString(name + "_bucket" + wrapMetricLabels(labels) + " " + strconv.FormatUint(series.buckets[bucketKey], 10) + "\n")
+			}
+			_, _ = buffered.WriteString(name + "_bucket" + wrapMetricLabels(joinMetricLabels(series.labels, `le="+Inf"`)) + " " + strconv.FormatUint(series.count, 10) + "\n")
+			_, _ = buffered.WriteString(name + "_sum" + wrapMetricLabels(series.labels) + " " + formatMetricValue(series.sum) + "\n")
+			_, _ = buffered.WriteString(name + "_count" + wrapMetricLabels(series.labels) + " " + strconv.FormatUint(series.count, 10) + "\n")
+		}
+	}
+
+	return buffered.Flush()
+}
+
+func (m *Metrics) Handler(ctx *fasthttp.RequestCtx) {
+	ctx.SetContentType("text/plain; version=0.0.4; charset=utf-8")
+	e := m.WritePrometheus(ctx)
+	if e != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+	}
+}
+
+// Instrument wraps next so every request it handles is counted and timed by method, route pattern and status,
+// the metrics are also stored on the context for the rate limiter and template instrumentation to find
+func (m *Metrics) Instrument(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		start := time.Now()
+		metricsContextKey.Set(ctx, m)
+
+		defer func() {
+			method := string(ctx.Method())
+			if !metricsKnownRequestMethods[method] {
+				method = "other"
+			}
+			route := metricsTimedOutRoute
+			if !IsTimedOut(ctx) {
+				route = GetRoutePattern(ctx)
+			}
+			if route == "" {
+				route = metricsUnmatchedRoute
+			}
+
+			m.IncrementCounter(MetricHttpRequests, map[string]string{
+				"method": method,
+				"route":  route,
+				"status": strconv.Itoa(getResponse(ctx).StatusCode()),
+			}, 1)
+			m.ObserveHistogram(MetricHttpRequestDuration, map[string]string{
+				"method": method,
+				"route":  route,
+			}, time.Since(start).Seconds())
+		}()
+
+		next(ctx)
+	}
+}
+
+func GetMetrics(ctx *fasthttp.RequestCtx) *Metrics {
+	if metrics, ok := metricsContextKey.Get(ctx).(*Metrics); ok {
+		return metrics
+	}
+
+	return nil
+}
+
+// GetRoutePattern returns the pattern of the route that matched the request, including the mount prefix
+func GetRoutePattern(ctx *fasthttp.RequestCtx) string {
+	if pattern, ok := ctx.UserValue(router.MatchedRoutePathParam).(string); ok {
+		return GetMountPrefix(ctx) + pattern
+	}
+
+	return ""
+}
+
+func RecordTemplateRender(ctx *fasthttp.RequestCtx, templateName string, duration time.Duration) {
+	if metrics := GetMetrics(ctx); metrics != nil {
+		metrics.ObserveHistogram(MetricTemplateRenderTime, map[string]string{"template": templateName}, duration.Seconds())
+	}
+}
+
+func recordRateLimited(ctx *fasthttp.RequestCtx) {
+	if metrics := GetMetrics(ctx); metrics != nil {
+		route := GetRoutePattern(ctx)
+		if route == "" {
+			route = "*"
+		}
+		metrics.IncrementCounter(MetricRateLimited, map[string]string{"route": route}, 1)
+	}
+}
+
+func formatMetricLabels(labels map[string]string) string {
+	var names []string
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var formatted []string
+	for _, name := range names {
+		formatted = append(formatted, name+`="`+escapeMetricLabel(labels[name])+`"`)
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+func joinMetricLabels(labels, extra string) string {
+	if labels == "" {
+		return extra
+	}
+
+	return labels + "," + extra
+}
+
+func wrapMetricLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+
+	return "{" + labels + "}"
+}
+
+func escapeMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func escapeMetricHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
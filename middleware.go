@@ -1,15 +1,18 @@
 package cbweb
 
 import (
+	"fmt"
 	"github.com/didip/tollbooth/config"
 	"github.com/didip/tollbooth_fasthttp"
 	"github.com/valyala/fasthttp"
+	"strings"
+	"time"
 )
 
-type Profiler interface {
-	Start()
-	End()
-}
+var (
+	// limiterStore holds the buckets of every MiddlewareHandler.Limiter, keyed by the limiter's address
+	limiterStore = NewMemoryRateLimitStore()
+)
 
 type MiddlewareHandler struct {
 	ErrorHandler ErrorHandler
@@ -17,11 +20,29 @@ type MiddlewareHandler struct {
 	middleware   []func(ctx *fasthttp.RequestCtx) (bool, error)
 	final        func(ctx *fasthttp.RequestCtx)
 	afterFinal   []func(ctx *fasthttp.RequestCtx) (bool, error)
+	always       []func(ctx *fasthttp.RequestCtx, outcome Outcome)
 	profiler     Profiler
+	limits       *RequestLimits
+	limitedPage  func(ctx *fasthttp.RequestCtx)
+}
+
+// Outcome describes how a MiddlewareHandler chain ended, it is given to the always phase
+type Outcome struct {
+	Status int
+	// Error is the first error returned by a middleware or after final handler
+	Error error
+	// Halted is set when a middleware or after final handler returned false
+	Halted bool
+	// Panic is the recovered value when the chain panicked, it is re-panicked after the always phase
+	Panic interface{}
+	// TimedOut is set when the route's HandlerTimeout fired, the ctx given to the always phase is then the
+	// timeout page's as the handler may still be using the request's
+	TimedOut bool
 }
 
 func (m MiddlewareHandler) AddMiddleware(middleware ...func(ctx *fasthttp.RequestCtx) (bool, error)) MiddlewareHandler {
-	m.middleware = append(m.middleware, middleware...)
+	// the full slice expression makes append copy, so handlers built from a shared stack never share middleware
+	m.middleware = append(m.middleware[:len(m.middleware):len(m.middleware)], middleware...)
 
 	return m
 }
@@ -38,6 +59,21 @@ func (m MiddlewareHandler) SetAfterFinal(after ...func(ctx *fasthttp.RequestCtx)
 	return m
 }
 
+// AddAlways adds handlers which run however the chain ended, including after a panic,
+// use them for cleanup, auditing and metrics which must not be skipped
+func (m MiddlewareHandler) AddAlways(always ...func(ctx *fasthttp.RequestCtx, outcome Outcome)) MiddlewareHandler {
+	m.always = append(m.always[:len(m.always):len(m.always)], always...)
+
+	return m
+}
+
+// SetLimitedPage renders the Limiter's rejection for html routes, pass a module's error page to keep the site's look
+func (m MiddlewareHandler) SetLimitedPage(page func(ctx *fasthttp.RequestCtx)) MiddlewareHandler {
+	m.limitedPage = page
+
+	return m
+}
+
 func (m MiddlewareHandler) SetProfiler(profiler Profiler) MiddlewareHandler {
 	m.profiler = profiler
 
@@ -45,45 +81,145 @@ func (m MiddlewareHandler) SetProfiler(profiler Profiler) MiddlewareHandler {
 }
 
 func (m MiddlewareHandler) Handle(ctx *fasthttp.RequestCtx) {
-	if m.profiler != nil {
-		m.profiler.Start()
+	if m.limits != nil && m.limits.HandlerTimeout > 0 {
+		m.limits.timeout(ctx, m.ErrorHandler, m.handleOutcome, m.handleTimedOut)
+		return
+	}
+
+	m.handleOutcome(ctx)
+}
+
+func (m MiddlewareHandler) handleOutcome(ctx *fasthttp.RequestCtx) {
+	endProfile := startProfile(ctx, m.profiler)
+
+	var outcome Outcome
+	defer func() {
+		outcome.Panic = recover()
+
+		// once a HandlerTimeout fired the server may be sending the timeout page, the ctx is left alone and
+		// the always phase has run on the page instead
+		if getHandlerTimeout(ctx).claim() {
+			outcome.Status = ctx.Response.StatusCode()
+			if outcome.Panic != nil {
+				outcome.Status = fasthttp.StatusInternalServerError
+			}
+
+			m.runAlways(ctx, outcome)
+
+			endProfile()
+		}
+
+		if outcome.Panic != nil {
+			panic(outcome.Panic)
+		}
+	}()
+
+	outcome.Halted, outcome.Error = m.handle(ctx)
+}
+
+// handleTimedOut runs the always phase on the timeout page, the ctx's user values are those from before the chain started
+func (m MiddlewareHandler) handleTimedOut(page *fasthttp.RequestCtx) {
+	m.runAlways(page, Outcome{
+		Status:   page.Response.StatusCode(),
+		Halted:   true,
+		TimedOut: true,
+	})
+}
+
+func (m MiddlewareHandler) runAlways(ctx *fasthttp.RequestCtx, outcome Outcome) {
+	for _, always := range m.always {
+		always(ctx, outcome)
+	}
+}
+
+func (m MiddlewareHandler) handle(ctx *fasthttp.RequestCtx) (bool, error) {
+	var firstError error
+
+	if m.limits != nil {
+		ok, _ := m.limits.Middleware(ctx)
+		if !ok {
+			return true, nil
+		}
 	}
 
 	for _, middleware := range m.middleware {
-		ok, e := middleware(ctx)
+		ok, e := m.runMiddleware(ctx, middleware)
 		if e != nil {
-			if m.ErrorHandler != nil {
-				m.ErrorHandler.Error(e)
+			HandleError(m.ErrorHandler, ctx, e)
+			if firstError == nil {
+				firstError = e
 			}
 		}
 		if !ok {
-			return
+			return true, firstError
 		}
 	}
 
 	if m.final != nil {
+		endSpan := StartSpan(ctx, "final "+getHandlerName(m.final))
 		m.final(ctx)
+		endSpan()
+	}
+
+	if isHandlerTimedOut(ctx) {
+		return true, firstError
 	}
 
 	for _, after := range m.afterFinal {
-		ok, e := after(ctx)
+		ok, e := m.runMiddleware(ctx, after)
 		if e != nil {
-			if m.ErrorHandler != nil {
-				m.ErrorHandler.Error(e)
+			HandleError(m.ErrorHandler, ctx, e)
+			if firstError == nil {
+				firstError = e
 			}
 		}
 		if !ok {
-			return
+			return true, firstError
 		}
 	}
-	if m.profiler != nil {
-		m.profiler.End()
+
+	return false, firstError
+}
+
+func (m MiddlewareHandler) runMiddleware(ctx *fasthttp.RequestCtx, middleware func(ctx *fasthttp.RequestCtx) (bool, error)) (bool, error) {
+	if !IsProfiling(ctx) {
+		return middleware(ctx)
 	}
+
+	defer StartSpan(ctx, "middleware "+getHandlerName(middleware))()
+
+	return middleware(ctx)
 }
 
 func (m MiddlewareHandler) HandleLimited() fasthttp.RequestHandler {
 	if m.Limiter != nil {
-		return tollbooth_fasthttp.LimitHandler(m.Handle, m.Limiter)
+		prefix := "cbweb-limiter:" + fmt.Sprintf("%p", m.Limiter) + ":"
+		return func(ctx *fasthttp.RequestCtx) {
+			// tollbooth builds the keys from the limiter's ip lookups and methods, the buckets are counted
+			// with the token bucket algorithm so the remaining requests are known for the headers. Like
+			// tollbooth's buckets they hold Max tokens and refill one every TTL, so the window is Max TTLs
+			window := m.Limiter.TTL * time.Duration(m.Limiter.Max)
+			proxies := getLimiterProxies(m.Limiter)
+			for _, keys := range tollbooth_fasthttp.BuildKeys(m.Limiter, ctx) {
+				if proxies != nil {
+					// every key starts with tollbooth's ip
+					keys[0] = GetRemoteIp(ctx, proxies)
+				}
+				result, e := RateLimitAlgorithm_TokenBucket.Take(limiterStore, prefix+strings.Join(keys, "|"), m.Limiter.Max, window, time.Now())
+				if e != nil {
+					HandleError(m.ErrorHandler, ctx, e)
+					continue
+				}
+				SetRateLimitHeaders(ctx, result)
+				if !result.Allowed {
+					writeRateLimitRejection(ctx, result, m.Limiter.StatusCode, m.Limiter.MessageContentType, m.Limiter.Message, m.limitedPage)
+					m.runAlways(ctx, Outcome{Status: ctx.Response.StatusCode(), Halted: true})
+					return
+				}
+			}
+
+			m.Handle(ctx)
+		}
 	}
 
 	return m.Handle
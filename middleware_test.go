@@ -0,0 +1,60 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"testing"
+	"time"
+)
+
+// routesModule is a module whose routes are set by the test
+type routesModule struct {
+	routes func(r *router.Router)
+}
+
+func (m *routesModule) SetRoutes(r *router.Router) {
+	m.routes(r)
+}
+
+func (m *routesModule) GetGlobalTemplates() map[string][]byte {
+	return nil
+}
+
+func (m *routesModule) SetGlobalTemplates(templates map[string][]byte) {}
+
+func TestHandleLimitedKeepsTollboothRate(t *testing.T) {
+	var outcomes []cbweb.Outcome
+	module := &routesModule{routes: func(r *router.Router) {
+		r.GET("/limited", cbweb.MiddlewareHandler{
+			Limiter: cbweb.NewLimiter(cbweb.LimiterConfig{Max: 10, Ttl: time.Minute}),
+		}.
+			AddAlways(func(ctx *fasthttp.RequestCtx, outcome cbweb.Outcome) {
+				outcomes = append(outcomes, outcome)
+			}).
+			SetFinal(func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyString("ok")
+			}).
+			HandleLimited(),
+		)
+	}}
+	h := cbwebtest.New(t, cbweb.Dependencies{}, module)
+
+	for request := 0; request < 10; request++ {
+		h.Get("/limited").AssertStatus(fasthttp.StatusOK).AssertHeader(cbweb.RateLimitLimitHeader, "10")
+	}
+
+	// the burst of Max is spent and one token comes back every Ttl, not Max tokens every Ttl
+	h.Get("/limited").
+		AssertStatus(fasthttp.StatusTooManyRequests).
+		AssertHeader(cbweb.RateLimitRemainingHeader, "0").
+		AssertHeader(fasthttp.HeaderRetryAfter, "60")
+
+	if len(outcomes) != 11 {
+		t.Fatalf("expected the always phase to run for all 11 requests, it ran %d times", len(outcomes))
+	}
+	if last := outcomes[10]; !last.Halted || last.Status != fasthttp.StatusTooManyRequests {
+		t.Errorf("expected the rejection's outcome to be halted with 429, got %+v", last)
+	}
+}
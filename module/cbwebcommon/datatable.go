@@ -14,12 +14,17 @@ type DataTable struct {
 	AjaxRoute         string
 	GroupByColumn     bool
 	GroupColumnOffset int
+	CspNonce          string
 }
 
 func (d *DataTable) GetTableId() template.JS {
 	return d.TableId
 }
 
+func (d *DataTable) GetCspNonce() string {
+	return d.CspNonce
+}
+
 func (d *DataTable) GetDataJson() template.JS {
 	jsonBytes, e := json.Marshal(d.Data)
 	if e == nil {
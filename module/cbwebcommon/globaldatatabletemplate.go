@@ -3,5 +3,5 @@ package cbwebcommon
 // DO NOT EDIT: This is autogenerated from datatable.gohtml
 // run go generate in the cb_auto_generate directory to regenerate this
 func getGlobalDataTableTemplate() []byte {
-	return []byte{123, 123, 45, 32, 47, 42, 103, 111, 116, 121, 112, 101, 58, 32, 103, 105, 116, 104, 117, 98, 46, 99, 111, 109, 47, 99, 111, 100, 105, 110, 103, 98, 101, 97, 114, 100, 47, 99, 98, 119, 101, 98, 47, 109, 111, 100, 117, 108, 101, 47, 99, 98, 119, 101, 98, 99, 111, 109, 109, 111, 110, 46, 68, 97, 116, 97, 84, 97, 98, 108, 101, 42, 47, 32, 45, 125, 125, 10, 123, 123, 32, 100, 101, 102, 105, 110, 101, 32, 34, 45, 103, 108, 111, 98, 97, 108, 45, 47, 99, 98, 119, 101, 98, 99, 111, 109, 109, 111, 110, 47, 100, 97, 116, 97, 116, 97, 98, 108, 101, 46, 103, 111, 104, 116, 109, 108, 34, 32, 125, 125, 10, 60, 115, 99, 114, 105, 112, 116, 32, 116, 121, 112, 101, 61, 34, 116, 101, 120, 116, 47, 106, 97, 118, 97, 115, 99, 114, 105, 112, 116, 34, 62, 10, 32, 32, 123, 10, 32, 32, 32, 32, 47, 47, 32, 85, 115, 101, 100, 32, 116, 111, 32, 102, 105, 103, 117, 114, 101, 32, 111, 117, 116, 32, 116, 104, 101, 32, 105, 110, 100, 101, 120, 32, 111, 102, 32, 116, 104, 101, 32, 99, 111, 108, 117, 109, 110, 115, 32, 98, 121, 32, 110, 97, 109, 101, 10, 32, 32, 32, 32, 108, 101, 116, 32, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 115, 32, 61, 32, 123, 123, 46, 71, 101, 116, 67, 111, 108, 117, 109, 110, 115, 74, 115, 111, 110, 125, 125, 59, 10, 10, 32, 32, 32, 32, 47, 47, 114, 101, 112, 108, 97, 99, 101, 32, 97, 108, 108, 32, 111, 102, 32, 116, 104, 101, 32, 104, 101, 97, 100, 101, 114, 115, 32, 119, 105, 116, 104, 32, 115, 101, 97, 114, 99, 104, 32, 98, 111, 120, 101, 115, 10, 32, 32, 32, 32, 36, 40, 39, 35, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 32, 46, 109, 97, 116, 101, 114, 105, 97, 108, 45, 116, 97, 98, 108, 101, 45, 115, 101, 97, 114, 99, 104, 97, 98, 108, 101, 39, 41, 46, 101, 97, 99, 104, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 116, 105, 116, 108, 101, 32, 61, 32, 36, 40, 116, 104, 105, 115, 41, 46, 116, 101, 120, 116, 40, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 116, 104, 105, 115, 41, 46, 104, 116, 109, 108, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 105, 110, 112, 117, 116, 32, 116, 121, 112, 101, 61, 34, 116, 101, 120, 116, 34, 32, 110, 97, 109, 101, 61, 34, 39, 32, 43, 32, 116, 105, 116, 108, 101, 32, 43, 32, 39, 34, 32, 112, 108, 97, 99, 101, 104, 111, 108, 100, 101, 114, 61, 34, 39, 32, 43, 32, 116, 105, 116, 108, 101, 32, 43, 32, 39, 34, 32, 47, 62, 39, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 108, 101, 116, 32, 101, 100, 105, 116, 97, 98, 108, 101, 67, 111, 108, 117, 109, 110, 115, 32, 61, 32, 91, 10, 32, 32, 32, 32, 32, 32, 123, 123, 114, 97, 110, 103, 101, 32, 46, 71, 101, 116, 67, 111, 108, 117, 109, 110, 115, 125, 125, 10, 32, 32, 32, 32, 32, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 101, 100, 105, 116, 97, 98, 108, 101, 58, 32, 123, 123, 105, 102, 32, 46, 71, 101, 116, 69, 100, 105, 116, 97, 98, 108, 101, 125, 125, 116, 114, 117, 101, 123, 123, 101, 108, 115, 101, 125, 125, 102, 97, 108, 115, 101, 123, 123, 101, 110, 100, 125, 125, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 110, 97, 109, 101, 58, 32, 34, 123, 123, 46, 71, 101, 116, 84, 105, 116, 108, 101, 125, 125, 34, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 101, 100, 105, 116, 97, 98, 108, 101, 78, 97, 109, 101, 58, 32, 34, 123, 123, 46, 71, 101, 116, 69, 100, 105, 116, 97, 98, 108, 101, 78, 97, 109, 101, 125, 125, 34, 10, 32, 32, 32, 32, 32, 32, 125, 44, 10, 32, 32, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 10, 32, 32, 32, 32, 93, 59, 10, 10, 32, 32, 32, 32, 47, 47, 32, 68, 97, 116, 97, 84, 97, 98, 108, 101, 10, 32, 32, 32, 32, 108, 101, 116, 32, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 32, 61, 10, 32, 32, 32, 32, 36, 40, 39, 35, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 39, 41, 46, 68, 97, 116, 97, 84, 97, 98, 108, 101, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 105, 102, 32, 46, 72, 97, 115, 68, 97, 116, 97, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 100, 97, 116, 97, 58, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 46, 71, 101, 116, 68, 97, 116, 97, 74, 115, 111, 110, 125, 125, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 105, 102, 32, 46, 73, 115, 65, 106, 97, 120, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 97, 106, 97, 120, 58, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 46, 71, 101, 116, 65, 106, 97, 120, 82, 111, 117, 116, 101, 125, 125, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 105, 102, 32, 46, 71, 114, 111, 117, 112, 66, 121, 67, 111, 108, 117, 109, 110, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 34, 99, 111, 108, 117, 109, 110, 68, 101, 102, 115, 34, 58, 32, 91, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 34, 118, 105, 115, 105, 98, 108, 101, 34, 58, 32, 102, 97, 108, 115, 101, 44, 32, 34, 116, 97, 114, 103, 101, 116, 115, 34, 58, 32, 123, 123, 46, 71, 114, 111, 117, 112, 67, 111, 108, 117, 109, 110, 79, 102, 102, 115, 101, 116, 125, 125, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 93, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 34, 111, 76, 97, 110, 103, 117, 97, 103, 101, 34, 58, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 34, 115, 73, 110, 102, 111, 34, 58, 32, 34, 95, 83, 84, 65, 82, 84, 95, 45, 95, 69, 78, 68, 95, 32, 111, 102, 32, 95, 84, 79, 84, 65, 76, 95, 34, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 34, 115, 76, 101, 110, 103, 116, 104, 77, 101, 110, 117, 34, 58, 32, 39, 60, 115, 112, 97, 110, 62, 82, 111, 119, 115, 32, 112, 101, 114, 32, 112, 97, 103, 101, 58, 60, 47, 115, 112, 97, 110, 62, 60, 115, 101, 108, 101, 99, 116, 32, 99, 108, 97, 115, 115, 61, 34, 98, 114, 111, 119, 115, 101, 114, 45, 100, 101, 102, 97, 117, 108, 116, 34, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 49, 48, 34, 62, 49, 48, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 50, 48, 34, 62, 50, 48, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 51, 48, 34, 62, 51, 48, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 52, 48, 34, 62, 52, 48, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 53, 48, 34, 62, 53, 48, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 111, 112, 116, 105, 111, 110, 32, 118, 97, 108, 117, 101, 61, 34, 45, 49, 34, 62, 65, 108, 108, 60, 47, 111, 112, 116, 105, 111, 110, 62, 39, 32, 43, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 47, 115, 101, 108, 101, 99, 116, 62, 60, 47, 100, 105, 118, 62, 39, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 98, 117, 116, 116, 111, 110, 115, 58, 32, 91, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 101, 120, 116, 101, 110, 100, 58, 32, 39, 99, 115, 118, 39, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 105, 108, 101, 110, 97, 109, 101, 58, 32, 39, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 39, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 116, 101, 120, 116, 58, 32, 39, 60, 105, 32, 99, 108, 97, 115, 115, 61, 34, 109, 97, 116, 101, 114, 105, 97, 108, 45, 105, 99, 111, 110, 115, 34, 62, 102, 105, 108, 101, 95, 100, 111, 119, 110, 108, 111, 97, 100, 60, 47, 105, 62, 39, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 93, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 98, 65, 117, 116, 111, 87, 105, 100, 116, 104, 58, 32, 102, 97, 108, 115, 101, 44, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 100, 114, 97, 119, 67, 97, 108, 108, 98, 97, 99, 107, 58, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 39, 35, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 32, 46, 109, 97, 116, 101, 114, 105, 97, 108, 45, 116, 97, 98, 108, 101, 45, 101, 100, 105, 116, 45, 114, 111, 119, 39, 41, 46, 111, 102, 102, 40, 39, 99, 108, 105, 99, 107, 39, 41, 46, 111, 110, 40, 39, 99, 108, 105, 99, 107, 39, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 101, 118, 116, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 100, 97, 116, 97, 32, 61, 32, 123, 125, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 116, 104, 105, 115, 41, 46, 112, 97, 114, 101, 110, 116, 40, 41, 46, 112, 97, 114, 101, 110, 116, 40, 41, 46, 102, 105, 110, 100, 40, 34, 116, 100, 34, 41, 46, 101, 97, 99, 104, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 101, 100, 105, 116, 97, 98, 108, 101, 67, 111, 108, 117, 109, 110, 115, 91, 107, 93, 46, 101, 100, 105, 116, 97, 98, 108, 101, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 118, 97, 108, 117, 101, 32, 61, 32, 36, 40, 116, 104, 105, 115, 41, 46, 116, 101, 120, 116, 40, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 118, 97, 108, 117, 101, 32, 33, 61, 61, 32, 34, 34, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 100, 97, 116, 97, 91, 101, 100, 105, 116, 97, 98, 108, 101, 67, 111, 108, 117, 109, 110, 115, 91, 107, 93, 46, 101, 100, 105, 116, 97, 98, 108, 101, 78, 97, 109, 101, 93, 32, 61, 32, 118, 97, 108, 117, 101, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 116, 121, 112, 101, 111, 102, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 32, 33, 61, 32, 34, 117, 110, 100, 101, 102, 105, 110, 101, 100, 34, 32, 38, 38, 32, 116, 121, 112, 101, 111, 102, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 91, 34, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 34, 93, 32, 61, 61, 32, 34, 102, 117, 110, 99, 116, 105, 111, 110, 34, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 91, 34, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 34, 93, 40, 100, 97, 116, 97, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 105, 102, 32, 46, 71, 114, 111, 117, 112, 66, 121, 67, 111, 108, 117, 109, 110, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 97, 112, 105, 32, 61, 32, 116, 104, 105, 115, 46, 97, 112, 105, 40, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 114, 111, 119, 115, 32, 61, 32, 97, 112, 105, 46, 114, 111, 119, 115, 40, 123, 112, 97, 103, 101, 58, 32, 39, 99, 117, 114, 114, 101, 110, 116, 39, 125, 41, 46, 110, 111, 100, 101, 115, 40, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 108, 97, 115, 116, 32, 61, 32, 110, 117, 108, 108, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 97, 112, 105, 46, 99, 111, 108, 117, 109, 110, 40, 123, 123, 46, 71, 114, 111, 117, 112, 67, 111, 108, 117, 109, 110, 79, 102, 102, 115, 101, 116, 125, 125, 44, 32, 123, 112, 97, 103, 101, 58, 32, 39, 99, 117, 114, 114, 101, 110, 116, 39, 125, 41, 46, 100, 97, 116, 97, 40, 41, 46, 101, 97, 99, 104, 40, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 103, 114, 111, 117, 112, 44, 32, 105, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 108, 97, 115, 116, 32, 33, 61, 61, 32, 103, 114, 111, 117, 112, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 114, 111, 119, 115, 41, 46, 101, 113, 40, 105, 41, 46, 98, 101, 102, 111, 114, 101, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 39, 60, 116, 114, 32, 99, 108, 97, 115, 115, 61, 34, 103, 114, 111, 117, 112, 34, 62, 60, 116, 100, 62, 39, 32, 43, 32, 103, 114, 111, 117, 112, 32, 43, 32, 39, 60, 47, 116, 100, 62, 60, 47, 116, 114, 62, 39, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 32, 61, 32, 103, 114, 111, 117, 112, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 123, 123, 101, 110, 100, 125, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 36, 40, 39, 46, 100, 97, 116, 97, 84, 97, 98, 108, 101, 115, 95, 102, 105, 108, 116, 101, 114, 39, 41, 46, 114, 101, 109, 111, 118, 101, 40, 41, 59, 10, 10, 32, 32, 32, 32, 47, 47, 32, 115, 116, 111, 114, 101, 115, 32, 97, 108, 108, 32, 97, 99, 116, 105, 118, 101, 32, 102, 105, 108, 116, 101, 114, 115, 10, 32, 32, 32, 32, 108, 101, 116, 32, 102, 105, 108, 116, 101, 114, 115, 32, 61, 32, 123, 125, 59, 10, 32, 32, 32, 32, 47, 47, 32, 102, 105, 108, 116, 101, 100, 32, 111, 117, 116, 32, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 32, 113, 117, 101, 114, 121, 32, 111, 98, 106, 101, 99, 116, 115, 32, 119, 104, 105, 99, 104, 32, 99, 111, 117, 108, 100, 32, 104, 97, 118, 101, 32, 98, 101, 101, 110, 32, 112, 97, 115, 116, 101, 100, 32, 105, 110, 32, 102, 114, 111, 109, 32, 108, 105, 110, 107, 32, 115, 111, 32, 119, 101, 32, 99, 97, 110, 32, 114, 101, 109, 111, 118, 101, 32, 116, 104, 101, 109, 32, 105, 102, 32, 116, 104, 101, 32, 102, 105, 108, 116, 101, 114, 32, 105, 115, 32, 99, 104, 97, 110, 103, 101, 100, 10, 32, 32, 32, 32, 108, 101, 116, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 32, 61, 32, 119, 105, 110, 100, 111, 119, 46, 108, 111, 99, 97, 116, 105, 111, 110, 46, 104, 114, 101, 102, 46, 114, 101, 112, 108, 97, 99, 101, 40, 47, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 92, 91, 91, 94, 92, 93, 93, 43, 92, 93, 61, 91, 94, 38, 93, 43, 47, 103, 44, 32, 39, 39, 41, 59, 10, 10, 32, 32, 32, 32, 47, 47, 32, 97, 116, 116, 97, 99, 104, 32, 116, 104, 101, 32, 102, 105, 108, 116, 101, 114, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 116, 111, 32, 101, 97, 99, 104, 32, 111, 102, 32, 116, 104, 101, 32, 105, 110, 112, 117, 116, 115, 10, 32, 32, 32, 32, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 46, 10, 32, 32, 32, 32, 99, 111, 108, 117, 109, 110, 115, 40, 41, 46, 101, 113, 40, 48, 41, 46, 101, 97, 99, 104, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 99, 111, 108, 73, 100, 120, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 39, 105, 110, 112, 117, 116, 39, 44, 32, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 46, 99, 111, 108, 117, 109, 110, 40, 99, 111, 108, 73, 100, 120, 41, 46, 104, 101, 97, 100, 101, 114, 40, 41, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 41, 46, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 111, 110, 40, 39, 107, 101, 121, 117, 112, 32, 99, 104, 97, 110, 103, 101, 39, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 117, 112, 100, 97, 116, 101, 81, 117, 101, 114, 121, 40, 99, 111, 108, 73, 100, 120, 44, 32, 36, 40, 116, 104, 105, 115, 41, 46, 97, 116, 116, 114, 40, 39, 110, 97, 109, 101, 39, 41, 44, 32, 116, 104, 105, 115, 46, 118, 97, 108, 117, 101, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 47, 47, 32, 85, 115, 101, 100, 32, 116, 111, 32, 103, 101, 116, 32, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 115, 32, 105, 102, 32, 116, 104, 101, 32, 117, 115, 101, 114, 32, 112, 97, 115, 116, 101, 100, 32, 105, 110, 32, 97, 32, 108, 105, 110, 107, 32, 99, 111, 110, 116, 97, 105, 110, 105, 110, 103, 32, 102, 105, 108, 116, 101, 114, 115, 10, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 112, 97, 114, 115, 101, 81, 117, 101, 114, 121, 40, 113, 115, 116, 114, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 113, 117, 101, 114, 121, 32, 61, 32, 123, 125, 59, 10, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 97, 32, 61, 32, 40, 113, 115, 116, 114, 91, 48, 93, 32, 61, 61, 61, 32, 39, 63, 39, 32, 63, 32, 113, 115, 116, 114, 46, 115, 117, 98, 115, 116, 114, 40, 49, 41, 32, 58, 32, 113, 115, 116, 114, 41, 46, 115, 112, 108, 105, 116, 40, 39, 38, 39, 41, 59, 10, 32, 32, 32, 32, 32, 32, 102, 111, 114, 32, 40, 108, 101, 116, 32, 105, 32, 61, 32, 48, 59, 32, 105, 32, 60, 32, 97, 46, 108, 101, 110, 103, 116, 104, 59, 32, 105, 43, 43, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 98, 32, 61, 32, 97, 91, 105, 93, 46, 115, 112, 108, 105, 116, 40, 39, 61, 39, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 113, 117, 101, 114, 121, 91, 100, 101, 99, 111, 100, 101, 85, 82, 73, 67, 111, 109, 112, 111, 110, 101, 110, 116, 40, 98, 91, 48, 93, 41, 93, 32, 61, 32, 100, 101, 99, 111, 100, 101, 85, 82, 73, 67, 111, 109, 112, 111, 110, 101, 110, 116, 40, 98, 91, 49, 93, 32, 124, 124, 32, 39, 39, 41, 59, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 114, 101, 116, 117, 114, 110, 32, 113, 117, 101, 114, 121, 59, 10, 32, 32, 32, 32, 125, 10, 10, 32, 32, 32, 32, 47, 47, 32, 65, 112, 112, 108, 121, 32, 97, 110, 121, 32, 102, 105, 108, 116, 101, 114, 115, 32, 119, 104, 105, 99, 104, 32, 119, 101, 114, 101, 32, 112, 97, 115, 116, 101, 100, 32, 105, 110, 116, 111, 32, 116, 104, 101, 32, 117, 114, 108, 10, 32, 32, 32, 32, 108, 101, 116, 32, 113, 117, 101, 114, 121, 32, 61, 32, 112, 97, 114, 115, 101, 81, 117, 101, 114, 121, 40, 119, 105, 110, 100, 111, 119, 46, 108, 111, 99, 97, 116, 105, 111, 110, 46, 115, 101, 97, 114, 99, 104, 41, 59, 10, 32, 32, 32, 32, 36, 46, 101, 97, 99, 104, 40, 113, 117, 101, 114, 121, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 107, 46, 105, 110, 100, 101, 120, 79, 102, 40, 39, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 91, 39, 41, 32, 33, 61, 61, 32, 45, 49, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 32, 61, 32, 107, 46, 114, 101, 112, 108, 97, 99, 101, 40, 47, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 92, 91, 40, 91, 94, 92, 93, 93, 43, 41, 92, 93, 47, 103, 44, 32, 34, 36, 49, 34, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 115, 46, 105, 110, 100, 101, 120, 79, 102, 40, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 41, 32, 33, 61, 61, 32, 45, 49, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 117, 112, 100, 97, 116, 101, 81, 117, 101, 114, 121, 40, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 115, 46, 105, 110, 100, 101, 120, 79, 102, 40, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 41, 44, 32, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 44, 32, 118, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 39, 105, 110, 112, 117, 116, 91, 110, 97, 109, 101, 61, 34, 39, 32, 43, 32, 99, 111, 108, 117, 109, 110, 78, 97, 109, 101, 32, 43, 32, 39, 34, 93, 39, 41, 46, 118, 97, 108, 40, 118, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 125, 41, 59, 10, 10, 32, 32, 32, 32, 47, 47, 32, 85, 112, 100, 97, 116, 101, 32, 116, 104, 101, 32, 99, 111, 108, 117, 109, 110, 32, 115, 101, 97, 114, 99, 104, 10, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 117, 112, 100, 97, 116, 101, 81, 117, 101, 114, 121, 40, 99, 111, 108, 73, 100, 120, 44, 32, 110, 97, 109, 101, 44, 32, 118, 97, 108, 117, 101, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 46, 10, 32, 32, 32, 32, 32, 32, 99, 111, 108, 117, 109, 110, 40, 99, 111, 108, 73, 100, 120, 41, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 46, 115, 101, 97, 114, 99, 104, 40, 118, 97, 108, 117, 101, 41, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 46, 100, 114, 97, 119, 40, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 118, 97, 108, 117, 101, 32, 33, 61, 32, 39, 39, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 102, 105, 108, 116, 101, 114, 115, 91, 110, 97, 109, 101, 93, 32, 61, 32, 118, 97, 108, 117, 101, 59, 10, 32, 32, 32, 32, 32, 32, 125, 32, 101, 108, 115, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 100, 101, 108, 101, 116, 101, 32, 102, 105, 108, 116, 101, 114, 115, 91, 110, 97, 109, 101, 93, 59, 10, 32, 32, 32, 32, 32, 32, 125, 10, 10, 32, 32, 32, 32, 32, 32, 36, 40, 39, 35, 102, 105, 108, 116, 101, 114, 115, 45, 101, 110, 97, 98, 108, 101, 100, 39, 41, 46, 101, 109, 112, 116, 121, 40, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 36, 46, 101, 97, 99, 104, 40, 102, 105, 108, 116, 101, 114, 115, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 36, 40, 39, 35, 102, 105, 108, 116, 101, 114, 115, 45, 101, 110, 97, 98, 108, 101, 100, 39, 41, 46, 97, 112, 112, 101, 110, 100, 40, 39, 60, 108, 105, 62, 39, 32, 43, 32, 107, 32, 43, 32, 39, 58, 32, 39, 32, 43, 32, 118, 32, 43, 32, 39, 60, 47, 108, 105, 62, 39, 41, 59, 10, 32, 32, 32, 32, 32, 32, 125, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 104, 105, 115, 116, 111, 114, 121, 46, 112, 117, 115, 104, 83, 116, 97, 116, 101, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 32, 61, 32, 39, 39, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 36, 46, 101, 97, 99, 104, 40, 102, 105, 108, 116, 101, 114, 115, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 32, 43, 61, 32, 39, 99, 111, 108, 117, 109, 110, 70, 105, 108, 116, 101, 114, 91, 39, 32, 43, 32, 107, 32, 43, 32, 39, 93, 61, 39, 32, 43, 32, 101, 110, 99, 111, 100, 101, 85, 82, 73, 40, 118, 41, 32, 43, 32, 39, 38, 39, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 108, 101, 110, 103, 116, 104, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 46, 105, 110, 100, 101, 120, 79, 102, 40, 39, 63, 39, 41, 32, 33, 61, 61, 32, 45, 49, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 46, 115, 117, 98, 115, 116, 114, 105, 110, 103, 40, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 46, 108, 101, 110, 103, 116, 104, 32, 45, 32, 49, 41, 32, 61, 61, 32, 39, 38, 39, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 110, 101, 119, 117, 114, 108, 32, 61, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 32, 43, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 115, 117, 98, 115, 116, 114, 105, 110, 103, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 48, 44, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 108, 101, 110, 103, 116, 104, 32, 45, 32, 49, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 32, 101, 108, 115, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 110, 101, 119, 117, 114, 108, 32, 61, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 32, 43, 32, 39, 38, 39, 32, 43, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 115, 117, 98, 115, 116, 114, 105, 110, 103, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 48, 44, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 108, 101, 110, 103, 116, 104, 32, 45, 32, 49, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 32, 101, 108, 115, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 110, 101, 119, 117, 114, 108, 32, 61, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 32, 43, 32, 39, 63, 39, 32, 43, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 115, 117, 98, 115, 116, 114, 105, 110, 103, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 48, 44, 32, 102, 105, 108, 116, 101, 114, 83, 116, 114, 105, 110, 103, 46, 108, 101, 110, 103, 116, 104, 32, 45, 32, 49, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 119, 105, 110, 100, 111, 119, 46, 104, 105, 115, 116, 111, 114, 121, 46, 112, 117, 115, 104, 83, 116, 97, 116, 101, 40, 123, 112, 97, 116, 104, 58, 32, 110, 101, 119, 117, 114, 108, 125, 44, 32, 39, 39, 44, 32, 110, 101, 119, 117, 114, 108, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 32, 101, 108, 115, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 119, 105, 110, 100, 111, 119, 46, 104, 105, 115, 116, 111, 114, 121, 46, 112, 117, 115, 104, 83, 116, 97, 116, 101, 40, 123, 112, 97, 116, 104, 58, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 125, 44, 32, 39, 39, 44, 32, 112, 114, 101, 69, 120, 105, 115, 116, 105, 110, 103, 85, 114, 108, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 125, 10, 10, 32, 32, 32, 32, 36, 40, 39, 35, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 32, 46, 109, 97, 116, 101, 114, 105, 97, 108, 45, 116, 97, 98, 108, 101, 45, 101, 100, 105, 116, 45, 114, 111, 119, 39, 41, 46, 111, 110, 40, 39, 99, 108, 105, 99, 107, 39, 44, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 101, 118, 116, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 100, 97, 116, 97, 32, 61, 32, 123, 125, 59, 10, 32, 32, 32, 32, 32, 32, 36, 40, 116, 104, 105, 115, 41, 46, 112, 97, 114, 101, 110, 116, 40, 41, 46, 112, 97, 114, 101, 110, 116, 40, 41, 46, 102, 105, 110, 100, 40, 34, 116, 100, 34, 41, 46, 101, 97, 99, 104, 40, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 102, 117, 110, 99, 116, 105, 111, 110, 32, 40, 107, 44, 32, 118, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 101, 100, 105, 116, 97, 98, 108, 101, 67, 111, 108, 117, 109, 110, 115, 91, 107, 93, 46, 101, 100, 105, 116, 97, 98, 108, 101, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 108, 101, 116, 32, 118, 97, 108, 117, 101, 32, 61, 32, 36, 40, 116, 104, 105, 115, 41, 46, 116, 101, 120, 116, 40, 41, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 118, 97, 108, 117, 101, 32, 33, 61, 61, 32, 34, 34, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 100, 97, 116, 97, 91, 101, 100, 105, 116, 97, 98, 108, 101, 67, 111, 108, 117, 109, 110, 115, 91, 107, 93, 46, 101, 100, 105, 116, 97, 98, 108, 101, 78, 97, 109, 101, 93, 32, 61, 32, 118, 97, 108, 117, 101, 59, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 41, 59, 10, 10, 32, 32, 32, 32, 32, 32, 105, 102, 32, 40, 116, 121, 112, 101, 111, 102, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 32, 33, 61, 32, 34, 117, 110, 100, 101, 102, 105, 110, 101, 100, 34, 32, 38, 38, 32, 116, 121, 112, 101, 111, 102, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 91, 34, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 34, 93, 32, 61, 61, 32, 34, 102, 117, 110, 99, 116, 105, 111, 110, 34, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 100, 97, 116, 97, 84, 97, 98, 108, 101, 69, 100, 105, 116, 67, 97, 108, 108, 98, 97, 99, 107, 91, 34, 123, 123, 46, 71, 101, 116, 84, 97, 98, 108, 101, 73, 100, 125, 125, 34, 93, 40, 100, 97, 116, 97, 41, 59, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 125, 41, 59, 10, 32, 32, 125, 10, 60, 47, 115, 99, 114, 105, 112, 116, 62, 10, 123, 123, 101, 110, 100, 125, 125}
+	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,47,109,111,100,117,108,101,47,99,98,119,101,98,99,111,109,109,111,110,46,68,97,116,97,84,97,98,108,101,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,100,97,116,97,116,97,98,108,101,46,103,111,104,116,109,108,34,32,125,125,10,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,123,10,32,32,32,32,47,47,32,85,115,101,100,32,116,111,32,102,105,103,117,114,101,32,111,117,116,32,116,104,101,32,105,110,100,101,120,32,111,102,32,116,104,101,32,99,111,108,117,109,110,115,32,98,121,32,110,97,109,101,10,32,32,32,32,108,101,116,32,99,111,108,117,109,110,78,97,109,101,115,32,61,32,123,123,46,71,101,116,67,111,108,117,109,110,115,74,115,111,110,125,125,59,10,10,32,32,32,32,47,47,114,101,112,108,97,99,101,32,97,108,108,32,111,102,32,116,104,101,32,104,101,97,100,101,114,115,32,119,105,116,104,32,115,101,97,114,99,104,32,98,111,120,101,115,10,32,32,32,32,36,40,39,35,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,32,46,109,97,116,101,114,105,97,108,45,116,97,98,108,101,45,115,101,97,114,99,104,97,98,108,101,39,41,46,101,97,99,104,40,10,32,32,32,32,32,32,32,32,32,32,32,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,116,105,116,108,101,32,61,32,36,40,116,104,105,115,41,46,116,101,120,116,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,36,40,116,104,105,115,41,46,104,116,109,108,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,105,110,112,117,116,32,116,121,112,101,61,34,116,101,120,116,34,32,110,97,109,101,61,34,39,32,43,32,116,105,116,108,101,32,43,32,39,34,32,112,108,97,99,101,104,111,108,100,101,114,61,34,39,32,43,32,116,105,116,108,101,32,43,32,39,34,32,47,62,39,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,41,59,10,10,32,32,32,32,108,101,116,32,101,100,105,116,97,98,108,101,67,111,108,117,109,110,115,32,61,32,91,10,32,32,32,32,32,32,123,123,114,97,110,103,101,32,46,71,101,116,67,111,108,117,109,110,115,125,125,10,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,101,100,105,116,97,98,108,101,58,32,123,123,105,102,32,46,71,101,116,69,100,105,116,97,98,108,101,125,125,116,114,117,101,123,123,101,108,115,101,125,125,102,97,108,115,101,123,123,101,110,100,125,125,44,10,32,32,32,32,32,32,32,32,110,97,109,101,58,32,34,123,123,46,71,101,116,84,105,116,108,101,125,125,34,44,10,32,32,32,32,32,32,32,32,101,100,105,116,97,98,108,101,78,97,109,101,58,32,34,123,123,46,71,101,116,69,100,105,116,97,98,108,101,78,97,109,101,125,125,34,10,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,93,59,10,10,32,32,32,32,47,47,32,68,97,116,97,84,97,98,108,101,10,32,32,32,32,108,101,116,32,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,32,61,10,32,32,32,32,36,40,39,35,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,39,41,46,68,97,116,97,84,97,98,108,101,40,10,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,72,97,115,68,97,116,97,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,58,32,32,32,32,32,32,32,32,123,123,46,71,101,116,68,97,116,97,74,115,111,110,125,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,73,115,65,106,97,120,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,97,106,97,120,58,32,32,32,32,32,32,32,32,123,123,46,71,101,116,65,106,97,120,82,111,117,116,101,125,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,71,114,111,117,112,66,121,67,111,108,117,109,110,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,99,111,108,117,109,110,68,101,102,115,34,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,34,118,105,115,105,98,108,101,34,58,32,102,97,108,115,101,44,32,34,116,97,114,103,101,116,115,34,58,32,123,123,46,71,114,111,117,112,67,111,108,117,109,110,79,102,102,115,101,116,125,125,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,111,76,97,110,103,117,97,103,101,34,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,115,73,110,102,111,34,58,32,34,95,83,84,65,82,84,95,45,95,69,78,68,95,32,111,102,32,95,84,79,84,65,76,95,34,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,34,115,76,101,110,103,116,104,77,101,110,117,34,58,32,39,60,115,112,97,110,62,82,111,119,115,32,112,101,114,32,112,97,103,101,58,60,47,115,112,97,110,62,60,115,101,108,101,99,116,32,99,108,97,115,115,61,34,98,114,111,119,115,101,114,45,100,101,102,97,117,108,116,34,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,49,48,34,62,49,48,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,50,48,34,62,50,48,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,51,48,34,62,51,48,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,52,48,34,62,52,48,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,53,48,34,62,53,48,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,111,112,116,105,111,110,32,118,97,108,117,101,61,34,45,49,34,62,65,108,108,60,47,111,112,116,105,111,110,62,39,32,43,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,47,115,101,108,101,99,116,62,60,47,100,105,118,62,39,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,98,117,116,116,111,110,115,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,101,120,116,101,110,100,58,32,39,99,115,118,39,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,102,105,108,101,110,97,109,101,58,32,39,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,39,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,116,101,120,116,58,32,39,60,105,32,99,108,97,115,115,61,34,109,97,116,101,114,105,97,108,45,105,99,111,110,115,34,62,102,105,108,101,95,100,111,119,110,108,111,97,100,60,47,105,62,39,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,98,65,117,116,111,87,105,100,116,104,58,32,102,97,108,115,101,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,100,114,97,119,67,97,108,108,98,97,99,107,58,32,102,117,110,99,116,105,111,110,32,40,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,35,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,32,46,109,97,116,101,114,105,97,108,45,116,97,98,108,101,45,101,100,105,116,45,114,111,119,39,41,46,111,102,102,40,39,99,108,105,99,107,39,41,46,111,110,40,39,99,108,105,99,107,39,44,32,102,117,110,99,116,105,111,110,32,40,101,118,116,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,100,97,116,97,32,61,32,123,125,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,36,40,116,104,105,115,41,46,112,97,114,101,110,116,40,41,46,112,97,114,101,110,116,40,41,46,102,105,110,100,40,34,116,100,34,41,46,101,97,99,104,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,100,105,116,97,98,108,101,67,111,108,117,109,110,115,91,107,93,46,101,100,105,116,97,98,108,101,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,36,40,116,104,105,115,41,46,116,101,120,116,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,118,97,108,117,101,32,33,61,61,32,34,34,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,91,101,100,105,116,97,98,108,101,67,111,108,117,109,110,115,91,107,93,46,101,100,105,116,97,98,108,101,78,97,109,101,93,32,61,32,118,97,108,117,101,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,41,59,10,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,116,121,112,101,111,102,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,32,33,61,32,34,117,110,100,101,102,105,110,101,100,34,32,38,38,32,116,121,112,101,111,102,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,91,34,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,34,93,32,61,61,32,34,102,117,110,99,116,105,111,110,34,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,91,34,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,34,93,40,100,97,116,97,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,71,114,111,117,112,66,121,67,111,108,117,109,110,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,97,112,105,32,61,32,116,104,105,115,46,97,112,105,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,114,111,119,115,32,61,32,97,112,105,46,114,111,119,115,40,123,112,97,103,101,58,32,39,99,117,114,114,101,110,116,39,125,41,46,110,111,100,101,115,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,108,97,115,116,32,61,32,110,117,108,108,59,10,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,97,112,105,46,99,111,108,117,109,110,40,123,123,46,71,114,111,117,112,67,111,108,117,109,110,79,102,102,115,101,116,125,125,44,32,123,112,97,103,101,58,32,39,99,117,114,114,101,110,116,39,125,41,46,100,97,116,97,40,41,46,101,97,99,104,40,102,117,110,99,116,105,111,110,32,40,103,114,111,117,112,44,32,105,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,108,97,115,116,32,33,61,61,32,103,114,111,117,112,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,36,40,114,111,119,115,41,46,101,113,40,105,41,46,98,101,102,111,114,101,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,60,116,114,32,99,108,97,115,115,61,34,103,114,111,117,112,34,62,60,116,100,62,39,32,43,32,103,114,111,117,112,32,43,32,39,60,47,116,100,62,60,47,116,114,62,39,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,41,59,10,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,97,115,116,32,61,32,103,114,111,117,112,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,41,59,10,10,32,32,32,32,36,40,39,46,100,97,116,97,84,97,98,108,101,115,95,102,105,108,116,101,114,39,41,46,114,101,109,111,118,101,40,41,59,10,10,32,32,32,32,47,47,32,115,116,111,114,101,115,32,97,108,108,32,97,99,116,105,118,101,32,102,105,108,116,101,114,115,10,32,32,32,32,108,101,116,32,102,105,108,116,101,114,115,32,61,32,123,125,59,10,32,32,32,32,47,47,32,102,105,108,116,101,100,32,111,117,116,32,99,111,108,117,109,110,70,105,108,116,101,114,32,113,117,101,114,121,32,111,98,106,101,99,116,115,32,119,104,105,99,104,32,99,111,117,108,100,32,104,97,118,101,32,98,101,101,110,32,112,97,115,116,101,100,32,105,110,32,102,114,111,109,32,108,105,110,107,32,115,111,32,119,101,32,99,97,110,32,114,101,109,111,118,101,32,116,104,101,109,32,105,102,32,116,104,101,32,102,105,108,116,101,114,32,105,115,32,99,104,97,110,103,101,100,10,32,32,32,32,108,101,116,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,32,61,32,119,105,110,100,111,119,46,108,111,99,97,116,105,111,110,46,104,114,101,102,46,114,101,112,108,97,99,101,40,47,99,111,108,117,109,110,70,105,108,116,101,114,92,91,91,94,92,93,93,43,92,93,61,91,94,38,93,43,47,103,44,32,39,39,41,59,10,10,32,32,32,32,47,47,32,97,116,116,97,99,104,32,116,104,101,32,102,105,108,116,101,114,32,102,117,110,99,116,105,111,110,32,116,111,32,101,97,99,104,32,111,102,32,116,104,101,32,105,110,112,117,116,115,10,32,32,32,32,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,46,10,32,32,32,32,99,111,108,117,109,110,115,40,41,46,101,113,40,48,41,46,101,97,99,104,40,10,32,32,32,32,32,32,32,32,32,32,32,32,102,117,110,99,116,105,111,110,32,40,99,111,108,73,100,120,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,105,110,112,117,116,39,44,32,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,46,99,111,108,117,109,110,40,99,111,108,73,100,120,41,46,104,101,97,100,101,114,40,41,10,32,32,32,32,32,32,32,32,32,32,32,32,41,46,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,111,110,40,39,107,101,121,117,112,32,99,104,97,110,103,101,39,44,32,102,117,110,99,116,105,111,110,32,40,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,117,112,100,97,116,101,81,117,101,114,121,40,99,111,108,73,100,120,44,32,36,40,116,104,105,115,41,46,97,116,116,114,40,39,110,97,109,101,39,41,44,32,116,104,105,115,46,118,97,108,117,101,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,41,59,10,10,32,32,32,32,47,47,32,85,115,101,100,32,116,111,32,103,101,116,32,99,111,108,117,109,110,70,105,108,116,101,114,115,32,105,102,32,116,104,101,32,117,115,101,114,32,112,97,115,116,101,100,32,105,110,32,97,32,108,105,110,107,32,99,111,110,116,97,105,110,105,110,103,32,102,105,108,116,101,114,115,10,32,32,32,32,102,117,110,99,116,105,111,110,32,112,97,114,115,101,81,117,101,114,121,40,113,115,116,114,41,32,123,10,32,32,32,32,32,32,108,101,116,32,113,117,101,114,121,32,61,32,123,125,59,10,32,32,32,32,32,32,108,101,116,32,97,32,61,32,40,113,115,116,114,91,48,93,32,61,61,61,32,39,63,39,32,63,32,113,115,116,114,46,115,117,98,115,116,114,40,49,41,32,58,32,113,115,116,114,41,46,115,112,108,105,116,40,39,38,39,41,59,10,32,32,32,32,32,32,102,111,114,32,40,108,101,116,32,105,32,61,32,48,59,32,105,32,60,32,97,46,108,101,110,103,116,104,59,32,105,43,43,41,32,123,10,32,32,32,32,32,32,32,32,108,101,116,32,98,32,61,32,97,91,105,93,46,115,112,108,105,116,40,39,61,39,41,59,10,32,32,32,32,32,32,32,32,113,117,101,114,121,91,100,101,99,111,100,101,85,82,73,67,111,109,112,111,110,101,110,116,40,98,91,48,93,41,93,32,61,32,100,101,99,111,100,101,85,82,73,67,111,109,112,111,110,101,110,116,40,98,91,49,93,32,124,124,32,39,39,41,59,10,32,32,32,32,32,32,125,10,32,32,32,32,32,32,114,101,116,117,114,110,32,113,117,101,114,121,59,10,32,32,32,32,125,10,10,32,32,32,32,47,47,32,65,112,112,108,121,32,97,110,121,32,102,105,108,116,101,114,115,32,119,104,105,99,104,32,119,101,114,101,32,112,97,115,116,101,100,32,105,110,116,111,32,116,104,101,32,117,114,108,10,32,32,32,32,108,101,116,32,113,117,101,114,121,32,61,32,112,97,114,115,101,81,117,101,114,121,40,119,105,110,100,111,119,46,108,111,99,97,116,105,111,110,46,115,101,97,114,99,104,41,59,10,32,32,32,32,36,46,101,97,99,104,40,113,117,101,114,121,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,105,102,32,40,107,46,105,110,100,101,120,79,102,40,39,99,111,108,117,109,110,70,105,108,116,101,114,91,39,41,32,33,61,61,32,45,49,41,32,123,10,32,32,32,32,32,32,32,32,108,101,116,32,99,111,108,117,109,110,78,97,109,101,32,61,32,107,46,114,101,112,108,97,99,101,40,47,99,111,108,117,109,110,70,105,108,116,101,114,92,91,40,91,94,92,93,93,43,41,92,93,47,103,44,32,34,36,49,34,41,59,10,10,32,32,32,32,32,32,32,32,105,102,32,40,99,111,108,117,109,110,78,97,109,101,115,46,105,110,100,101,120,79,102,40,99,111,108,117,109,110,78,97,109,101,41,32,33,61,61,32,45,49,41,32,123,10,32,32,32,32,32,32,32,32,32,32,117,112,100,97,116,101,81,117,101,114,121,40,99,111,108,117,109,110,78,97,109,101,115,46,105,110,100,101,120,79,102,40,99,111,108,117,109,110,78,97,109,101,41,44,32,99,111,108,117,109,110,78,97,109,101,44,32,118,41,59,10,32,32,32,32,32,32,32,32,32,32,36,40,39,105,110,112,117,116,91,110,97,109,101,61,34,39,32,43,32,99,111,108,117,109,110,78,97,109,101,32,43,32,39,34,93,39,41,46,118,97,108,40,118,41,59,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,41,59,10,10,32,32,32,32,47,47,32,85,112,100,97,116,101,32,116,104,101,32,99,111,108,117,109,110,32,115,101,97,114,99,104,10,32,32,32,32,102,117,110,99,116,105,111,110,32,117,112,100,97,116,101,81,117,101,114,121,40,99,111,108,73,100,120,44,32,110,97,109,101,44,32,118,97,108,117,101,41,32,123,10,32,32,32,32,32,32,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,46,10,32,32,32,32,32,32,99,111,108,117,109,110,40,99,111,108,73,100,120,41,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,46,115,101,97,114,99,104,40,118,97,108,117,101,41,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,46,100,114,97,119,40,41,59,10,10,32,32,32,32,32,32,105,102,32,40,118,97,108,117,101,32,33,61,32,39,39,41,32,123,10,32,32,32,32,32,32,32,32,102,105,108,116,101,114,115,91,110,97,109,101,93,32,61,32,118,97,108,117,101,59,10,32,32,32,32,32,32,125,32,101,108,115,101,32,123,10,32,32,32,32,32,32,32,32,100,101,108,101,116,101,32,102,105,108,116,101,114,115,91,110,97,109,101,93,59,10,32,32,32,32,32,32,125,10,10,32,32,32,32,32,32,36,40,39,35,102,105,108,116,101,114,115,45,101,110,97,98,108,101,100,39,41,46,101,109,112,116,121,40,41,59,10,10,32,32,32,32,32,32,36,46,101,97,99,104,40,102,105,108,116,101,114,115,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,36,40,39,35,102,105,108,116,101,114,115,45,101,110,97,98,108,101,100,39,41,46,97,112,112,101,110,100,40,39,60,108,105,62,39,32,43,32,107,32,43,32,39,58,32,39,32,43,32,118,32,43,32,39,60,47,108,105,62,39,41,59,10,32,32,32,32,32,32,125,41,59,10,10,32,32,32,32,32,32,105,102,32,40,104,105,115,116,111,114,121,46,112,117,115,104,83,116,97,116,101,41,32,123,10,32,32,32,32,32,32,32,32,108,101,116,32,102,105,108,116,101,114,83,116,114,105,110,103,32,61,32,39,39,59,10,10,32,32,32,32,32,32,32,32,36,46,101,97,99,104,40,102,105,108,116,101,114,115,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,102,105,108,116,101,114,83,116,114,105,110,103,32,43,61,32,39,99,111,108,117,109,110,70,105,108,116,101,114,91,39,32,43,32,107,32,43,32,39,93,61,39,32,43,32,101,110,99,111,100,101,85,82,73,40,118,41,32,43,32,39,38,39,10,32,32,32,32,32,32,32,32,125,41,59,10,10,32,32,32,32,32,32,32,32,105,102,32,40,102,105,108,116,101,114,83,116,114,105,110,103,46,108,101,110,103,116,104,41,32,123,10,32,32,32,32,32,32,32,32,32,32,105,102,32,40,112,114,101,69,120,105,115,116,105,110,103,85,114,108,46,105,110,100,101,120,79,102,40,39,63,39,41,32,33,61,61,32,45,49,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,112,114,101,69,120,105,115,116,105,110,103,85,114,108,46,115,117,98,115,116,114,105,110,103,40,112,114,101,69,120,105,115,116,105,110,103,85,114,108,46,108,101,110,103,116,104,32,45,32,49,41,32,61,61,32,39,38,39,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,110,101,119,117,114,108,32,61,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,32,43,32,102,105,108,116,101,114,83,116,114,105,110,103,46,115,117,98,115,116,114,105,110,103,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,48,44,32,102,105,108,116,101,114,83,116,114,105,110,103,46,108,101,110,103,116,104,32,45,32,49,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,125,32,101,108,115,101,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,110,101,119,117,114,108,32,61,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,32,43,32,39,38,39,32,43,32,102,105,108,116,101,114,83,116,114,105,110,103,46,115,117,98,115,116,114,105,110,103,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,48,44,32,102,105,108,116,101,114,83,116,114,105,110,103,46,108,101,110,103,116,104,32,45,32,49,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,10,32,32,32,32,32,32,32,32,32,32,125,32,101,108,115,101,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,110,101,119,117,114,108,32,61,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,32,43,32,39,63,39,32,43,32,102,105,108,116,101,114,83,116,114,105,110,103,46,115,117,98,115,116,114,105,110,103,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,48,44,32,102,105,108,116,101,114,83,116,114,105,110,103,46,108,101,110,103,116,104,32,45,32,49,41,59,10,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,119,105,110,100,111,119,46,104,105,115,116,111,114,121,46,112,117,115,104,83,116,97,116,101,40,123,112,97,116,104,58,32,110,101,119,117,114,108,125,44,32,39,39,44,32,110,101,119,117,114,108,41,59,10,32,32,32,32,32,32,32,32,125,32,101,108,115,101,32,123,10,32,32,32,32,32,32,32,32,32,32,119,105,110,100,111,119,46,104,105,115,116,111,114,121,46,112,117,115,104,83,116,97,116,101,40,123,112,97,116,104,58,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,125,44,32,39,39,44,32,112,114,101,69,120,105,115,116,105,110,103,85,114,108,41,59,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,10,10,32,32,32,32,36,40,39,35,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,32,46,109,97,116,101,114,105,97,108,45,116,97,98,108,101,45,101,100,105,116,45,114,111,119,39,41,46,111,110,40,39,99,108,105,99,107,39,44,32,102,117,110,99,116,105,111,110,32,40,101,118,116,41,32,123,10,32,32,32,32,32,32,108,101,116,32,100,97,116,97,32,61,32,123,125,59,10,32,32,32,32,32,32,36,40,116,104,105,115,41,46,112,97,114,101,110,116,40,41,46,112,97,114,101,110,116,40,41,46,102,105,110,100,40,34,116,100,34,41,46,101,97,99,104,40,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,100,105,116,97,98,108,101,67,111,108,117,109,110,115,91,107,93,46,101,100,105,116,97,98,108,101,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,36,40,116,104,105,115,41,46,116,101,120,116,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,118,97,108,117,101,32,33,61,61,32,34,34,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,91,101,100,105,116,97,98,108,101,67,111,108,117,109,110,115,91,107,93,46,101,100,105,116,97,98,108,101,78,97,109,101,93,32,61,32,118,97,108,117,101,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,41,59,10,10,32,32,32,32,32,32,105,102,32,40,116,121,112,101,111,102,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,32,33,61,32,34,117,110,100,101,102,105,110,101,100,34,32,38,38,32,116,121,112,101,111,102,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,91,34,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,34,93,32,61,61,32,34,102,117,110,99,116,105,111,110,34,41,32,123,10,32,32,32,32,32,32,32,32,100,97,116,97,84,97,98,108,101,69,100,105,116,67,97,108,108,98,97,99,107,91,34,123,123,46,71,101,116,84,97,98,108,101,73,100,125,125,34,93,40,100,97,116,97,41,59,10,32,32,32,32,32,32,125,10,32,32,32,32,125,41,59,10,32,32,125,10,60,47,115,99,114,105,112,116,62,10,123,123,101,110,100,125,125}
 }
@@ -3,5 +3,5 @@ package cbwebcommon
 // DO NOT EDIT: This is autogenerated from flashtoast.gohtml
 // run go generate in the cb_auto_generate directory to regenerate this
 func getGlobalFlashToastTemplate() []byte {
-	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,102,108,97,115,104,116,111,97,115,116,46,103,111,104,116,109,108,34,32,125,125,10,32,32,32,32,123,123,32,105,102,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,70,108,97,115,104,46,72,97,115,77,101,115,115,97,103,101,115,32,34,116,111,97,115,116,34,32,125,125,10,32,32,32,32,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,62,10,32,32,32,32,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,70,108,97,115,104,46,71,101,116,77,101,115,115,97,103,101,115,32,34,116,111,97,115,116,34,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,77,46,116,111,97,115,116,40,123,104,116,109,108,58,32,123,123,46,77,101,115,115,97,103,101,125,125,44,32,99,108,97,115,115,101,115,58,32,123,123,46,84,121,112,101,125,125,125,41,59,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,32,101,110,100,32,125,125,10,123,123,32,101,110,100,32,125,125}
+	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,102,108,97,115,104,116,111,97,115,116,46,103,111,104,116,109,108,34,32,125,125,10,32,32,32,32,123,123,32,105,102,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,70,108,97,115,104,46,72,97,115,77,101,115,115,97,103,101,115,32,34,116,111,97,115,116,34,32,125,125,10,32,32,32,32,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,70,108,97,115,104,46,71,101,116,77,101,115,115,97,103,101,115,32,34,116,111,97,115,116,34,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,77,46,116,111,97,115,116,40,123,104,116,109,108,58,32,123,123,46,77,101,115,115,97,103,101,125,125,44,32,99,108,97,115,115,101,115,58,32,123,123,46,84,121,112,101,125,125,125,41,59,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,32,101,110,100,32,125,125,10,123,123,32,101,110,100,32,125,125}
 }
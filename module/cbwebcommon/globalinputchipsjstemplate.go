@@ -3,5 +3,5 @@ package cbwebcommon
 // DO NOT EDIT: This is autogenerated from inputchipsjs.gohtml
 // run go generate in the cb_auto_generate directory to regenerate this
 func getGlobalInputChipsJsTemplate() []byte {
-	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,47,99,98,102,111,114,109,46,70,105,101,108,100,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,105,110,112,117,116,99,104,105,112,115,106,115,46,103,111,104,116,109,108,34,32,125,125,10,32,32,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,62,10,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,108,101,116,32,101,108,101,109,101,110,116,32,61,32,36,40,39,46,99,104,105,112,115,45,105,110,112,117,116,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,59,10,32,32,32,32,32,32,32,32,101,108,101,109,101,110,116,46,99,104,105,112,115,40,123,10,32,32,32,32,32,32,32,32,32,32,112,108,97,99,101,104,111,108,100,101,114,58,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,44,10,32,32,32,32,32,32,32,32,32,32,97,117,116,111,99,111,109,112,108,101,116,101,79,112,116,105,111,110,115,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,58,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,114,97,110,103,101,32,46,71,101,116,79,112,116,105,111,110,115,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,58,32,110,117,108,108,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,108,105,109,105,116,58,32,32,32,32,32,73,110,102,105,110,105,116,121,44,10,32,32,32,32,32,32,32,32,32,32,32,32,109,105,110,76,101,110,103,116,104,58,32,49,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,100,97,116,97,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,114,97,110,103,101,32,46,71,101,116,79,112,116,105,111,110,115,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,73,115,83,101,108,101,99,116,101,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,116,97,103,58,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,111,110,67,104,105,112,65,100,100,58,32,102,117,110,99,116,105,111,110,32,40,101,108,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,108,101,109,101,110,116,46,104,97,115,67,108,97,115,115,40,34,99,104,105,112,115,45,101,114,114,111,114,34,41,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,101,108,101,109,101,110,116,46,114,101,109,111,118,101,67,108,97,115,115,40,34,99,104,105,112,115,45,101,114,114,111,114,34,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,101,114,114,111,114,32,61,32,101,108,101,109,101,110,116,46,112,97,114,101,110,116,40,41,46,102,105,110,100,40,34,46,101,114,114,111,114,45,116,101,120,116,34,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,114,114,111,114,46,108,101,110,103,116,104,32,62,32,48,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,101,114,114,111,114,46,101,109,112,116,121,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,34,34,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,46,101,97,99,104,40,77,46,67,104,105,112,115,46,103,101,116,73,110,115,116,97,110,99,101,40,101,108,101,109,101,110,116,41,46,99,104,105,112,115,68,97,116,97,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,118,97,108,117,101,32,43,61,32,118,46,116,97,103,32,43,32,34,44,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,35,102,111,114,109,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,46,118,97,108,40,118,97,108,117,101,46,115,108,105,99,101,40,48,44,32,45,49,41,41,59,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,111,110,67,104,105,112,68,101,108,101,116,101,58,32,102,117,110,99,116,105,111,110,32,40,101,108,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,34,34,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,46,101,97,99,104,40,77,46,67,104,105,112,115,46,103,101,116,73,110,115,116,97,110,99,101,40,101,108,101,109,101,110,116,41,46,99,104,105,112,115,68,97,116,97,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,118,97,108,117,101,32,43,61,32,118,46,116,97,103,32,43,32,34,44,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,35,102,111,114,109,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,46,118,97,108,40,118,97,108,117,101,46,115,108,105,99,101,40,48,44,32,45,49,41,41,59,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,125,10,32,32,32,32,60,47,115,99,114,105,112,116,62,10,123,123,32,101,110,100,32,125,125}
+	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,47,99,98,102,111,114,109,46,70,105,101,108,100,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,105,110,112,117,116,99,104,105,112,115,106,115,46,103,111,104,116,109,108,34,32,125,125,10,32,32,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,46,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,108,101,116,32,101,108,101,109,101,110,116,32,61,32,36,40,39,46,99,104,105,112,115,45,105,110,112,117,116,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,59,10,32,32,32,32,32,32,32,32,101,108,101,109,101,110,116,46,99,104,105,112,115,40,123,10,32,32,32,32,32,32,32,32,32,32,112,108,97,99,101,104,111,108,100,101,114,58,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,44,10,32,32,32,32,32,32,32,32,32,32,97,117,116,111,99,111,109,112,108,101,116,101,79,112,116,105,111,110,115,58,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,100,97,116,97,58,32,32,32,32,32,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,114,97,110,103,101,32,46,71,101,116,79,112,116,105,111,110,115,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,58,32,110,117,108,108,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,108,105,109,105,116,58,32,32,32,32,32,73,110,102,105,110,105,116,121,44,10,32,32,32,32,32,32,32,32,32,32,32,32,109,105,110,76,101,110,103,116,104,58,32,49,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,100,97,116,97,58,32,91,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,114,97,110,103,101,32,46,71,101,116,79,112,116,105,111,110,115,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,105,102,32,46,73,115,83,101,108,101,99,116,101,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,116,97,103,58,32,39,123,123,46,71,101,116,76,97,98,101,108,125,125,39,125,44,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,101,110,100,125,125,10,32,32,32,32,32,32,32,32,32,32,93,44,10,32,32,32,32,32,32,32,32,32,32,111,110,67,104,105,112,65,100,100,58,32,102,117,110,99,116,105,111,110,32,40,101,108,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,108,101,109,101,110,116,46,104,97,115,67,108,97,115,115,40,34,99,104,105,112,115,45,101,114,114,111,114,34,41,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,101,108,101,109,101,110,116,46,114,101,109,111,118,101,67,108,97,115,115,40,34,99,104,105,112,115,45,101,114,114,111,114,34,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,101,114,114,111,114,32,61,32,101,108,101,109,101,110,116,46,112,97,114,101,110,116,40,41,46,102,105,110,100,40,34,46,101,114,114,111,114,45,116,101,120,116,34,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,105,102,32,40,101,114,114,111,114,46,108,101,110,103,116,104,32,62,32,48,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,101,114,114,111,114,46,101,109,112,116,121,40,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,34,34,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,46,101,97,99,104,40,77,46,67,104,105,112,115,46,103,101,116,73,110,115,116,97,110,99,101,40,101,108,101,109,101,110,116,41,46,99,104,105,112,115,68,97,116,97,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,118,97,108,117,101,32,43,61,32,118,46,116,97,103,32,43,32,34,44,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,35,102,111,114,109,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,46,118,97,108,40,118,97,108,117,101,46,115,108,105,99,101,40,48,44,32,45,49,41,41,59,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,32,32,111,110,67,104,105,112,68,101,108,101,116,101,58,32,102,117,110,99,116,105,111,110,32,40,101,108,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,108,101,116,32,118,97,108,117,101,32,61,32,34,34,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,46,101,97,99,104,40,77,46,67,104,105,112,115,46,103,101,116,73,110,115,116,97,110,99,101,40,101,108,101,109,101,110,116,41,46,99,104,105,112,115,68,97,116,97,44,32,102,117,110,99,116,105,111,110,32,40,107,44,32,118,41,32,123,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,118,97,108,117,101,32,43,61,32,118,46,116,97,103,32,43,32,34,44,34,10,32,32,32,32,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,32,32,32,32,32,32,36,40,39,35,102,111,114,109,45,123,123,46,71,101,116,78,97,109,101,125,125,39,41,46,118,97,108,40,118,97,108,117,101,46,115,108,105,99,101,40,48,44,32,45,49,41,41,59,10,32,32,32,32,32,32,32,32,32,32,125,44,10,32,32,32,32,32,32,32,32,125,41,59,10,32,32,32,32,32,32,125,10,32,32,32,32,60,47,115,99,114,105,112,116,62,10,123,123,32,101,110,100,32,125,125}
 }
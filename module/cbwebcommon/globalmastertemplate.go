@@ -3,5 +3,5 @@ package cbwebcommon
 // DO NOT EDIT: This is autogenerated from master.gohtml
 // run go generate in the cb_auto_generate directory to regenerate this
 func getGlobalMasterTemplate() []byte {
-	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,60,104,116,109,108,62,10,60,104,101,97,100,62,10,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,53,55,120,53,55,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,53,55,120,53,55,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,54,48,120,54,48,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,54,48,120,54,48,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,55,50,120,55,50,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,55,50,120,55,50,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,55,54,120,55,54,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,55,54,120,55,54,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,49,52,120,49,49,52,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,49,52,120,49,49,52,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,50,48,120,49,50,48,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,50,48,120,49,50,48,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,52,52,120,49,52,52,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,52,52,120,49,52,52,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,53,50,120,49,53,50,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,53,50,120,49,53,50,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,56,48,120,49,56,48,34,32,104,114,101,102,61,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,56,48,120,49,56,48,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,49,57,50,120,49,57,50,34,32,32,104,114,101,102,61,34,47,105,109,103,47,97,110,100,114,111,105,100,45,105,99,111,110,45,49,57,50,120,49,57,50,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,51,50,120,51,50,34,32,104,114,101,102,61,34,47,105,109,103,47,102,97,118,105,99,111,110,45,51,50,120,51,50,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,57,54,120,57,54,34,32,104,114,101,102,61,34,47,105,109,103,47,102,97,118,105,99,111,110,45,57,54,120,57,54,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,49,54,120,49,54,34,32,104,114,101,102,61,34,47,105,109,103,47,102,97,118,105,99,111,110,45,49,54,120,49,54,46,112,110,103,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,109,97,110,105,102,101,115,116,34,32,104,114,101,102,61,34,47,109,97,110,105,102,101,115,116,46,106,115,111,110,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,109,115,97,112,112,108,105,99,97,116,105,111,110,45,84,105,108,101,67,111,108,111,114,34,32,99,111,110,116,101,110,116,61,34,35,102,102,102,102,102,102,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,109,115,97,112,112,108,105,99,97,116,105,111,110,45,84,105,108,101,73,109,97,103,101,34,32,99,111,110,116,101,110,116,61,34,47,105,109,103,47,109,115,45,105,99,111,110,45,49,52,52,120,49,52,52,46,112,110,103,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,116,104,101,109,101,45,99,111,108,111,114,34,32,99,111,110,116,101,110,116,61,34,35,102,102,102,102,102,102,34,62,10,10,32,32,60,108,105,110,107,32,104,114,101,102,61,34,104,116,116,112,115,58,47,47,102,111,110,116,115,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,105,99,111,110,63,102,97,109,105,108,121,61,77,97,116,101,114,105,97,108,43,73,99,111,110,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,62,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,83,116,114,105,110,103,32,34,47,99,115,115,47,109,97,105,110,46,109,105,110,46,99,115,115,34,32,125,125,34,32,32,109,101,100,105,97,61,34,115,99,114,101,101,110,44,112,114,111,106,101,99,116,105,111,110,34,47,62,10,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,118,105,101,119,112,111,114,116,34,32,99,111,110,116,101,110,116,61,34,119,105,100,116,104,61,100,101,118,105,99,101,45,119,105,100,116,104,44,32,105,110,105,116,105,97,108,45,115,99,97,108,101,61,49,46,48,34,47,62,10,32,32,32,32,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,72,101,97,100,32,125,125,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,72,101,97,100,73,110,108,105,110,101,32,125,125,10,32,32,60,115,116,121,108,101,62,10,32,32,32,32,123,123,32,46,67,115,115,32,125,125,10,32,32,60,47,115,116,121,108,101,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,72,101,97,100,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,72,101,97,100,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,62,10,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,32,32,60,116,105,116,108,101,62,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,84,105,116,108,101,32,125,125,60,47,116,105,116,108,101,62,10,60,47,104,101,97,100,62,10,60,98,111,100,121,32,99,108,97,115,115,61,34,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,66,111,100,121,67,108,97,115,115,101,115,32,125,125,34,62,10,10,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,66,111,100,121,32,125,125,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,72,101,97,100,73,110,108,105,110,101,32,125,125,10,32,32,60,115,116,121,108,101,62,10,32,32,32,32,123,123,32,46,67,115,115,32,125,125,10,32,32,60,47,115,116,121,108,101,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,66,111,100,121,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,66,111,100,121,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,62,10,32,32,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,101,110,100,32,125,125,10,32,32,123,123,45,32,116,101,109,112,108,97,116,101,32,34,99,111,110,116,101,110,116,34,32,46,32,45,125,125,10,60,115,99,114,105,112,116,32,115,114,99,61,34,104,116,116,112,115,58,47,47,99,111,100,101,46,106,113,117,101,114,121,46,99,111,109,47,106,113,117,101,114,121,45,51,46,52,46,49,46,109,105,110,46,106,115,34,32,105,110,116,101,103,114,105,116,121,61,34,115,104,97,50,53,54,45,67,83,88,111,114,88,118,90,99,84,107,97,105,120,54,89,118,111,54,72,112,112,99,90,71,101,116,98,89,77,71,87,83,70,108,66,119,56,72,102,67,74,111,61,34,32,99,114,111,115,115,111,114,105,103,105,110,61,34,97,110,111,110,121,109,111,117,115,34,62,60,47,115,99,114,105,112,116,62,10,32,32,60,33,45,45,74,97,118,97,83,99,114,105,112,116,32,97,116,32,101,110,100,32,111,102,32,98,111,100,121,32,102,111,114,32,111,112,116,105,109,105,122,101,100,32,108,111,97,100,105,110,103,45,45,62,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,83,116,114,105,110,103,32,34,47,106,115,47,108,105,98,114,97,114,105,101,115,46,109,105,110,46,106,115,34,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,74,115,80,111,115,116,66,111,100,121,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,80,111,115,116,66,111,100,121,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,62,10,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,116,101,109,112,108,97,116,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,102,108,97,115,104,116,111,97,115,116,46,103,111,104,116,109,108,34,32,46,32,45,125,125,10,123,123,45,32,116,101,109,112,108,97,116,101,32,34,106,97,118,97,115,99,114,105,112,116,34,32,46,32,45,125,125,10,60,47,98,111,100,121,62,10,10,60,47,104,116,109,108,62}
+	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,60,104,116,109,108,62,10,60,104,101,97,100,62,10,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,53,55,120,53,55,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,53,55,120,53,55,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,54,48,120,54,48,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,54,48,120,54,48,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,55,50,120,55,50,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,55,50,120,55,50,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,55,54,120,55,54,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,55,54,120,55,54,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,49,52,120,49,49,52,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,49,52,120,49,49,52,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,50,48,120,49,50,48,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,50,48,120,49,50,48,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,52,52,120,49,52,52,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,52,52,120,49,52,52,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,53,50,120,49,53,50,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,53,50,120,49,53,50,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,97,112,112,108,101,45,116,111,117,99,104,45,105,99,111,110,34,32,115,105,122,101,115,61,34,49,56,48,120,49,56,48,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,112,112,108,101,45,105,99,111,110,45,49,56,48,120,49,56,48,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,49,57,50,120,49,57,50,34,32,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,97,110,100,114,111,105,100,45,105,99,111,110,45,49,57,50,120,49,57,50,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,51,50,120,51,50,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,102,97,118,105,99,111,110,45,51,50,120,51,50,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,57,54,120,57,54,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,102,97,118,105,99,111,110,45,57,54,120,57,54,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,105,99,111,110,34,32,116,121,112,101,61,34,105,109,97,103,101,47,112,110,103,34,32,115,105,122,101,115,61,34,49,54,120,49,54,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,102,97,118,105,99,111,110,45,49,54,120,49,54,46,112,110,103,34,32,125,125,34,62,10,32,32,60,108,105,110,107,32,114,101,108,61,34,109,97,110,105,102,101,115,116,34,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,109,97,110,105,102,101,115,116,46,106,115,111,110,34,32,125,125,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,109,115,97,112,112,108,105,99,97,116,105,111,110,45,84,105,108,101,67,111,108,111,114,34,32,99,111,110,116,101,110,116,61,34,35,102,102,102,102,102,102,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,109,115,97,112,112,108,105,99,97,116,105,111,110,45,84,105,108,101,73,109,97,103,101,34,32,99,111,110,116,101,110,116,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,105,109,103,47,109,115,45,105,99,111,110,45,49,52,52,120,49,52,52,46,112,110,103,34,32,125,125,34,62,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,116,104,101,109,101,45,99,111,108,111,114,34,32,99,111,110,116,101,110,116,61,34,35,102,102,102,102,102,102,34,62,10,10,32,32,60,108,105,110,107,32,104,114,101,102,61,34,104,116,116,112,115,58,47,47,102,111,110,116,115,46,103,111,111,103,108,101,97,112,105,115,46,99,111,109,47,105,99,111,110,63,102,97,109,105,108,121,61,77,97,116,101,114,105,97,108,43,73,99,111,110,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,62,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,83,116,114,105,110,103,32,34,47,99,115,115,47,109,97,105,110,46,109,105,110,46,99,115,115,34,32,125,125,34,32,32,109,101,100,105,97,61,34,115,99,114,101,101,110,44,112,114,111,106,101,99,116,105,111,110,34,47,62,10,10,32,32,60,109,101,116,97,32,110,97,109,101,61,34,118,105,101,119,112,111,114,116,34,32,99,111,110,116,101,110,116,61,34,119,105,100,116,104,61,100,101,118,105,99,101,45,119,105,100,116,104,44,32,105,110,105,116,105,97,108,45,115,99,97,108,101,61,49,46,48,34,47,62,10,32,32,123,123,32,99,115,114,102,77,101,116,97,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,114,102,32,125,125,10,32,32,32,32,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,72,101,97,100,32,125,125,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,72,101,97,100,73,110,108,105,110,101,32,125,125,10,32,32,60,115,116,121,108,101,123,123,32,119,105,116,104,32,36,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,123,123,32,46,67,115,115,32,125,125,10,32,32,60,47,115,116,121,108,101,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,72,101,97,100,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,72,101,97,100,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,36,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,32,32,60,116,105,116,108,101,62,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,84,105,116,108,101,32,125,125,60,47,116,105,116,108,101,62,10,60,47,104,101,97,100,62,10,60,98,111,100,121,32,99,108,97,115,115,61,34,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,66,111,100,121,67,108,97,115,115,101,115,32,125,125,34,62,10,10,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,66,111,100,121,32,125,125,10,32,32,60,108,105,110,107,32,116,121,112,101,61,34,116,101,120,116,47,99,115,115,34,32,114,101,108,61,34,115,116,121,108,101,115,104,101,101,116,34,32,104,114,101,102,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,67,115,115,66,111,100,121,73,110,108,105,110,101,32,125,125,10,32,32,60,115,116,121,108,101,123,123,32,119,105,116,104,32,36,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,123,123,32,46,67,115,115,32,125,125,10,32,32,60,47,115,116,121,108,101,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,66,111,100,121,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,66,111,100,121,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,36,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,101,110,100,32,125,125,10,32,32,123,123,45,32,116,101,109,112,108,97,116,101,32,34,99,111,110,116,101,110,116,34,32,46,32,45,125,125,10,60,115,99,114,105,112,116,32,115,114,99,61,34,104,116,116,112,115,58,47,47,99,111,100,101,46,106,113,117,101,114,121,46,99,111,109,47,106,113,117,101,114,121,45,51,46,52,46,49,46,109,105,110,46,106,115,34,32,105,110,116,101,103,114,105,116,121,61,34,115,104,97,50,53,54,45,67,83,88,111,114,88,118,90,99,84,107,97,105,120,54,89,118,111,54,72,112,112,99,90,71,101,116,98,89,77,71,87,83,70,108,66,119,56,72,102,67,74,111,61,34,32,99,114,111,115,115,111,114,105,103,105,110,61,34,97,110,111,110,121,109,111,117,115,34,62,60,47,115,99,114,105,112,116,62,10,32,32,60,33,45,45,74,97,118,97,83,99,114,105,112,116,32,97,116,32,101,110,100,32,111,102,32,98,111,100,121,32,102,111,114,32,111,112,116,105,109,105,122,101,100,32,108,111,97,100,105,110,103,45,45,62,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,83,116,114,105,110,103,32,34,47,106,115,47,108,105,98,114,97,114,105,101,115,46,109,105,110,46,106,115,34,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,36,46,97,106,97,120,83,101,116,117,112,40,123,10,32,32,32,32,32,32,98,101,102,111,114,101,83,101,110,100,58,32,102,117,110,99,116,105,111,110,32,40,120,104,114,44,32,115,101,116,116,105,110,103,115,41,32,123,10,32,32,32,32,32,32,32,32,118,97,114,32,116,111,107,101,110,32,61,32,36,40,39,109,101,116,97,91,110,97,109,101,61,34,99,115,114,102,45,116,111,107,101,110,34,93,39,41,46,97,116,116,114,40,39,99,111,110,116,101,110,116,39,41,59,10,32,32,32,32,32,32,32,32,105,102,32,40,116,111,107,101,110,32,38,38,32,33,47,94,40,71,69,84,124,72,69,65,68,124,79,80,84,73,79,78,83,124,84,82,65,67,69,41,36,47,105,46,116,101,115,116,40,115,101,116,116,105,110,103,115,46,116,121,112,101,41,32,38,38,32,33,115,101,116,116,105,110,103,115,46,99,114,111,115,115,68,111,109,97,105,110,41,32,123,10,32,32,32,32,32,32,32,32,32,32,120,104,114,46,115,101,116,82,101,113,117,101,115,116,72,101,97,100,101,114,40,36,40,39,109,101,116,97,91,110,97,109,101,61,34,99,115,114,102,45,104,101,97,100,101,114,34,93,39,41,46,97,116,116,114,40,39,99,111,110,116,101,110,116,39,41,44,32,116,111,107,101,110,41,59,10,32,32,32,32,32,32,32,32,125,10,32,32,32,32,32,32,125,10,32,32,32,32,125,41,59,10,32,32,60,47,115,99,114,105,112,116,62,10,123,123,45,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,86,105,101,119,73,110,99,108,117,100,101,115,32,125,125,10,32,32,32,32,123,123,45,32,105,102,32,46,84,121,112,101,46,73,115,74,115,80,111,115,116,66,111,100,121,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,32,115,114,99,61,34,123,123,32,103,101,116,67,100,110,85,114,108,84,101,109,112,108,97,116,101,85,82,76,32,46,83,114,99,32,125,125,34,62,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,108,115,101,32,105,102,32,46,84,121,112,101,46,73,115,74,115,80,111,115,116,66,111,100,121,73,110,108,105,110,101,32,125,125,10,32,32,60,115,99,114,105,112,116,32,116,121,112,101,61,34,116,101,120,116,47,106,97,118,97,115,99,114,105,112,116,34,123,123,32,119,105,116,104,32,36,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,67,115,112,78,111,110,99,101,32,125,125,32,110,111,110,99,101,61,34,123,123,32,46,32,125,125,34,123,123,32,101,110,100,32,125,125,62,10,32,32,32,32,123,123,32,46,74,115,32,125,125,10,32,32,60,47,115,99,114,105,112,116,62,10,32,32,32,32,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,101,110,100,32,125,125,10,123,123,45,32,116,101,109,112,108,97,116,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,102,108,97,115,104,116,111,97,115,116,46,103,111,104,116,109,108,34,32,46,32,45,125,125,10,123,123,45,32,116,101,109,112,108,97,116,101,32,34,106,97,118,97,115,99,114,105,112,116,34,32,46,32,45,125,125,10,60,47,98,111,100,121,62,10,10,60,47,104,116,109,108,62}
 }
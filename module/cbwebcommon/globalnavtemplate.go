@@ -3,5 +3,5 @@ package cbwebcommon
 // DO NOT EDIT: This is autogenerated from nav.gohtml
 // run go generate in the cb_auto_generate directory to regenerate this
 func getGlobalNavTemplate() []byte {
-	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,110,97,118,46,103,111,104,116,109,108,34,32,125,125,10,32,32,60,100,105,118,32,99,108,97,115,115,61,34,110,97,118,98,97,114,45,102,105,120,101,100,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,34,62,10,32,32,32,32,60,110,97,118,62,10,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,110,97,118,45,119,114,97,112,112,101,114,34,62,10,32,32,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,114,111,119,34,62,10,32,32,32,32,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,99,111,108,32,115,49,50,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,35,34,32,100,97,116,97,45,116,97,114,103,101,116,61,34,115,108,105,100,101,45,111,117,116,34,32,99,108,97,115,115,61,34,115,105,100,101,110,97,118,45,116,114,105,103,103,101,114,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,32,115,104,111,119,45,111,110,45,115,109,97,108,108,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,105,32,99,108,97,115,115,61,34,109,97,116,101,114,105,97,108,45,105,99,111,110,115,34,62,109,101,110,117,60,47,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,115,112,97,110,32,99,108,97,115,115,61,34,98,114,97,110,100,45,108,111,103,111,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,32,115,104,111,119,45,111,110,45,115,109,97,108,108,34,62,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,80,97,103,101,84,105,116,108,101,32,125,125,60,47,115,112,97,110,62,10,32,32,32,32,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,60,47,110,97,118,62,10,32,32,60,47,100,105,118,62,10,10,32,32,60,117,108,32,105,100,61,34,115,108,105,100,101,45,111,117,116,34,32,99,108,97,115,115,61,34,115,105,100,101,110,97,118,32,115,105,100,101,110,97,118,45,102,105,120,101,100,34,62,10,32,32,32,32,60,108,105,62,10,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,47,34,32,99,108,97,115,115,61,34,98,114,97,110,100,45,108,111,103,111,34,62,123,123,32,103,101,116,66,114,97,110,100,78,97,109,101,32,125,125,10,32,32,32,32,32,32,32,32,60,115,112,97,110,32,99,108,97,115,115,61,34,104,105,100,101,45,111,110,45,115,109,97,108,108,45,111,110,108,121,32,118,101,114,115,105,111,110,34,62,10,9,9,9,32,32,118,123,123,32,103,101,116,86,101,114,115,105,111,110,83,116,114,105,110,103,32,125,125,10,32,32,32,32,32,32,60,47,115,112,97,110,62,10,32,32,32,32,32,32,60,47,97,62,10,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,123,123,32,36,112,97,116,104,32,58,61,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,80,97,116,104,32,125,125,10,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,78,97,118,73,116,101,109,115,32,125,125,10,32,32,32,32,32,32,32,32,32,32,123,123,32,105,102,32,101,113,32,40,108,101,110,32,46,83,117,98,78,97,118,73,116,101,109,115,41,32,48,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,32,99,108,97,115,115,61,34,123,123,32,105,102,32,111,114,32,46,65,99,116,105,118,101,32,40,101,113,32,36,112,97,116,104,32,46,83,114,99,41,32,125,125,97,99,116,105,118,101,123,123,32,101,110,100,32,125,125,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,123,123,32,46,83,114,99,32,125,125,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,108,115,101,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,99,108,97,115,115,61,34,115,117,98,104,101,97,100,101,114,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,83,117,98,78,97,118,73,116,101,109,115,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,32,99,108,97,115,115,61,34,123,123,32,105,102,32,111,114,32,46,65,99,116,105,118,101,32,40,101,113,32,36,112,97,116,104,32,46,83,114,99,41,32,125,125,97,99,116,105,118,101,123,123,32,101,110,100,32,125,125,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,123,123,32,46,83,114,99,32,125,125,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,60,47,117,108,62,10,123,123,32,101,110,100,32,125,125}
+	return []byte{123,123,45,32,47,42,103,111,116,121,112,101,58,32,103,105,116,104,117,98,46,99,111,109,47,99,111,100,105,110,103,98,101,97,114,100,47,99,98,119,101,98,46,84,121,112,101,104,105,110,116,105,110,103,86,105,101,119,77,111,100,101,108,42,47,32,45,125,125,10,123,123,32,100,101,102,105,110,101,32,34,45,103,108,111,98,97,108,45,47,99,98,119,101,98,99,111,109,109,111,110,47,110,97,118,46,103,111,104,116,109,108,34,32,125,125,10,32,32,60,100,105,118,32,99,108,97,115,115,61,34,110,97,118,98,97,114,45,102,105,120,101,100,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,34,62,10,32,32,32,32,60,110,97,118,62,10,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,110,97,118,45,119,114,97,112,112,101,114,34,62,10,32,32,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,114,111,119,34,62,10,32,32,32,32,32,32,32,32,32,32,60,100,105,118,32,99,108,97,115,115,61,34,99,111,108,32,115,49,50,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,35,34,32,100,97,116,97,45,116,97,114,103,101,116,61,34,115,108,105,100,101,45,111,117,116,34,32,99,108,97,115,115,61,34,115,105,100,101,110,97,118,45,116,114,105,103,103,101,114,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,32,115,104,111,119,45,111,110,45,115,109,97,108,108,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,105,32,99,108,97,115,115,61,34,109,97,116,101,114,105,97,108,45,105,99,111,110,115,34,62,109,101,110,117,60,47,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,115,112,97,110,32,99,108,97,115,115,61,34,98,114,97,110,100,45,108,111,103,111,32,104,105,100,101,45,111,110,45,108,97,114,103,101,45,111,110,108,121,32,115,104,111,119,45,111,110,45,115,109,97,108,108,34,62,123,123,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,80,97,103,101,84,105,116,108,101,32,125,125,60,47,115,112,97,110,62,10,32,32,32,32,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,32,32,60,47,100,105,118,62,10,32,32,32,32,60,47,110,97,118,62,10,32,32,60,47,100,105,118,62,10,10,32,32,60,117,108,32,105,100,61,34,115,108,105,100,101,45,111,117,116,34,32,99,108,97,115,115,61,34,115,105,100,101,110,97,118,32,115,105,100,101,110,97,118,45,102,105,120,101,100,34,62,10,32,32,32,32,60,108,105,62,10,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,123,123,32,109,111,117,110,116,80,97,116,104,32,34,47,34,32,125,125,34,32,99,108,97,115,115,61,34,98,114,97,110,100,45,108,111,103,111,34,62,123,123,32,103,101,116,66,114,97,110,100,78,97,109,101,32,125,125,10,32,32,32,32,32,32,32,32,60,115,112,97,110,32,99,108,97,115,115,61,34,104,105,100,101,45,111,110,45,115,109,97,108,108,45,111,110,108,121,32,118,101,114,115,105,111,110,34,62,10,9,9,9,32,32,118,123,123,32,103,101,116,86,101,114,115,105,111,110,83,116,114,105,110,103,32,125,125,10,32,32,32,32,32,32,60,47,115,112,97,110,62,10,32,32,32,32,32,32,60,47,97,62,10,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,123,123,32,36,112,97,116,104,32,58,61,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,80,97,116,104,32,125,125,10,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,71,101,116,77,97,115,116,101,114,86,105,101,119,77,111,100,101,108,46,71,101,116,78,97,118,73,116,101,109,115,32,125,125,10,32,32,32,32,32,32,32,32,32,32,123,123,32,105,102,32,101,113,32,40,108,101,110,32,46,83,117,98,78,97,118,73,116,101,109,115,41,32,48,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,32,99,108,97,115,115,61,34,123,123,32,105,102,32,111,114,32,46,65,99,116,105,118,101,32,40,101,113,32,36,112,97,116,104,32,46,83,114,99,41,32,125,125,97,99,116,105,118,101,123,123,32,101,110,100,32,125,125,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,123,123,32,46,83,114,99,32,125,125,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,108,115,101,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,99,108,97,115,115,61,34,115,117,98,104,101,97,100,101,114,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,32,114,97,110,103,101,32,46,83,117,98,78,97,118,73,116,101,109,115,32,125,125,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,108,105,32,99,108,97,115,115,61,34,123,123,32,105,102,32,111,114,32,46,65,99,116,105,118,101,32,40,101,113,32,36,112,97,116,104,32,46,83,114,99,41,32,125,125,97,99,116,105,118,101,123,123,32,101,110,100,32,125,125,34,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,97,32,104,114,101,102,61,34,123,123,32,46,83,114,99,32,125,125,34,62,123,123,32,46,84,105,116,108,101,32,125,125,60,47,97,62,10,32,32,32,32,32,32,32,32,32,32,32,32,32,32,60,47,108,105,62,10,32,32,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,32,32,32,32,123,123,32,101,110,100,32,125,125,10,32,32,60,47,117,108,62,10,123,123,32,101,110,100,32,125,125}
 }
@@ -32,7 +32,12 @@ type Module struct {
 	WebAssets        FileOpener
 	ErrorHandler     ErrorHandler
 	Logger           Logger
+	// CdnUrl is where WebAssets are served from outside dev, such as https://cdn.example.com/admin.
+	// The mount prefix is not added to it
+	CdnUrl           string
+	MiddlewareStacks *cbweb.MiddlewareStacks
 	globalTemplates  map[string][]byte
+	mount            cbweb.Mount
 }
 
 type FileOpener interface {
@@ -96,6 +101,23 @@ func (m *Module) SetGlobalTemplates(templates map[string][]byte) {
 	m.globalTemplates = templates
 }
 
+func (m *Module) SetMount(mount cbweb.Mount) {
+	m.mount = mount
+}
+
+func (m *Module) GetMount() cbweb.Mount {
+	return m.mount
+}
+
+// GetModuleName includes the mount, so the module can be mounted more than once
+func (m *Module) GetModuleName() string {
+	if m.mount.IsRoot() {
+		return "cbwebcommon"
+	}
+
+	return "cbwebcommon " + m.mount.String()
+}
+
 func (m *Module) DefaultFileServer(ctx *fasthttp.RequestCtx) {
 	uri := string(ctx.URI().Path())
 	if strings.Contains(uri, "?") {
@@ -117,10 +139,15 @@ func (m *Module) DefaultFileServer(ctx *fasthttp.RequestCtx) {
 		m.FiveHundredError(ctx)
 		return
 	}
-	if !ctx.IfModifiedSince(stat.ModTime()) {
+	etag := cbweb.FileETag(stat.ModTime(), stat.Size())
+	ifNoneMatch := string(ctx.Request.Header.Peek(fasthttp.HeaderIfNoneMatch))
+	if cbweb.ETagMatches(ifNoneMatch, etag, true) || (ifNoneMatch == "" && !ctx.IfModifiedSince(stat.ModTime())) {
+		_ = f.Close()
 		ctx.NotModified()
+		ctx.Response.Header.Set(fasthttp.HeaderETag, etag)
 		return
 	}
+	ctx.Response.Header.Set(fasthttp.HeaderETag, etag)
 	ctx.Response.Header.SetLastModified(stat.ModTime())
 	ctx.Response.Header.Set("Cache-Control", "max-age=31536000")
 	ctx.Response.Header.Set("Content-Type", mime.TypeByExtension(filepath.Ext(stat.Name())))
@@ -129,18 +156,29 @@ func (m *Module) DefaultFileServer(ctx *fasthttp.RequestCtx) {
 }
 
 func (m *Module) ExecuteViewModel(ctx *fasthttp.RequestCtx, viewModel cbweb.ExecutableViewModel) error {
-	cacheKey := "ExecuteViewModel:" + viewModel.GetMainTemplate()
+	start := time.Now()
+	defer func() {
+		cbweb.RecordTemplateRender(ctx, viewModel.GetMainTemplate(), time.Since(start))
+	}()
+
+	// the template funcs belong to this module, so another mount sharing the cache must not get its templates
+	cacheKey := m.getTemplateCacheKeyPrefix() + "ExecuteViewModel:" + viewModel.GetMainTemplate()
 	if m.TemplateCache != nil {
 		if cache, ok := m.TemplateCache.Get(cacheKey); ok {
+			defer cbweb.StartSpan(ctx, "template execute "+viewModel.GetMainTemplate())()
 			return cache.(*templates.InheritanceMultiTemplate).ExecuteTemplate(ctx, viewModel.GetMainTemplate(), viewModel)
 		}
 	}
+	endSpan := cbweb.StartSpan(ctx, "template generate "+viewModel.GetMainTemplate())
 	t, e := m.GenerateTemplate(viewModel.GetTemplates())
+	endSpan()
 	if e != nil {
 		return e
 	}
 
+	endSpan = cbweb.StartSpan(ctx, "template execute "+viewModel.GetMainTemplate())
 	e = t.ExecuteTemplate(ctx, viewModel.GetMainTemplate(), viewModel)
+	endSpan()
 
 	if m.TemplateCache != nil {
 		m.TemplateCache.Set(cacheKey, t, time.Hour*24)
@@ -156,9 +194,10 @@ func (m *Module) GenerateTemplate(fileNames []string) (*templates.InheritanceMul
 	}
 
 	t := templates.NewInheritanceMultiTemplate(templates.Dependencies{
-		Funcs:         mergedTemplateFuncs,
-		Cache:         m.TemplateCache != nil,
-		CacheProvider: m.TemplateCache,
+		Funcs:          mergedTemplateFuncs,
+		Cache:          m.TemplateCache != nil,
+		CacheProvider:  m.TemplateCache,
+		CacheKeyPrefix: m.getTemplateCacheKeyPrefix(),
 	})
 
 	for templateName, templateBytes := range m.globalTemplates {
@@ -202,6 +241,9 @@ func (m *Module) getDefaultTemplateFuncs() template.FuncMap {
 		"getCdnUrlTemplateURL": m.getDefaultCdnUrlTemplateUrl,
 		"getVersionString":     m.getDefaultVersionString,
 		"getBrandName":         m.getDefaultBrandName,
+		"mountPath":            m.getMountPath,
+		"csrfInput":            m.getCsrfInput,
+		"csrfMeta":             m.getCsrfMeta,
 	}
 }
 
@@ -209,16 +251,40 @@ func (m *Module) getDefaultCdnUrlTemplateUrl(nonCdnUrl template.URL) string {
 	return m.getDefaultCdnUrl(string(nonCdnUrl))
 }
 
+func (m *Module) getMountPath(path string) string {
+	if strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//") {
+		return m.mount.Prefix + path
+	}
+
+	return path
+}
+
+func (m *Module) getCsrfInput(token cbweb.CsrfToken) template.HTML {
+	return token.Input()
+}
+
+func (m *Module) getCsrfMeta(token cbweb.CsrfToken) template.HTML {
+	return token.Meta()
+}
+
+func (m *Module) getTemplateCacheKeyPrefix() string {
+	return m.GetModuleName() + ":"
+}
+
+// getDefaultCdnUrl only adds the mount prefix to urls served by this module, not those on a CDN or another host
 func (m *Module) getDefaultCdnUrl(nonCdnUrl string) string {
-	if strings.HasPrefix(nonCdnUrl, "http://") || strings.HasPrefix(nonCdnUrl, "https://") {
+	lowerUrl := strings.ToLower(nonCdnUrl)
+	if strings.HasPrefix(lowerUrl, "http://") || strings.HasPrefix(lowerUrl, "https://") || strings.HasPrefix(nonCdnUrl, "//") {
 		return nonCdnUrl
 	}
 	if m.Env == "dev" {
-		return nonCdnUrl + "?" + strconv.Itoa(int(time.Now().Unix()))
-	} else {
-		//todo cdn
-		return nonCdnUrl + "?" + m.Version
+		return m.getMountPath(nonCdnUrl) + "?" + strconv.Itoa(int(time.Now().Unix()))
 	}
+	if m.CdnUrl != "" && strings.HasPrefix(nonCdnUrl, "/") {
+		return strings.TrimRight(m.CdnUrl, "/") + nonCdnUrl + "?" + m.Version
+	}
+
+	return m.getMountPath(nonCdnUrl) + "?" + m.Version
 }
 
 func (m *Module) getDefaultVersionString() string {
@@ -240,6 +306,6 @@ func (m *Module) DefaultFourOFourError(ctx *fasthttp.RequestCtx) {
 }
 
 func (m *Module) Redirect(ctx *fasthttp.RequestCtx, uri string) {
-	ctx.Response.Header.Set("Location", uri)
+	ctx.Response.Header.Set("Location", cbweb.MountPath(ctx, uri))
 	ctx.Response.SetStatusCode(fasthttp.StatusFound)
 }
@@ -0,0 +1,77 @@
+package cbwebcommon_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/codingbeard/cbweb/module/cbwebcommon"
+	"github.com/valyala/fasthttp"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+type pageViewModel struct{}
+
+func (p pageViewModel) GetTemplates() []string {
+	return []string{"page.gohtml"}
+}
+
+func (p pageViewModel) GetMainTemplate() string {
+	return "page.gohtml"
+}
+
+func newMountedModule(t *testing.T, cache cbweb.CacheProvider, mount cbweb.Mount, cdnUrl string) *cbwebcommon.Module {
+	dir := t.TempDir()
+	page := []byte(`{{ mountPath "/page" }} {{ getCdnUrlString "/css/main.css" }} {{ getCdnUrlString "//cdn.example.org/lib.js" }}`)
+	if e := ioutil.WriteFile(filepath.Join(dir, "page.gohtml"), page, 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	module := &cbwebcommon.Module{
+		Env:           "prod",
+		Version:       "1",
+		CdnUrl:        cdnUrl,
+		TemplateCache: cache,
+		TemplatesBox:  http.Dir(dir),
+	}
+	module.SetDefaults()
+	module.SetMount(mount)
+
+	return module
+}
+
+func executePage(t *testing.T, module *cbwebcommon.Module) string {
+	ctx := &fasthttp.RequestCtx{}
+	if e := module.ExecuteViewModel(ctx, pageViewModel{}); e != nil {
+		t.Fatal(e)
+	}
+
+	return string(ctx.Response.Body())
+}
+
+func TestExecuteViewModelPerMount(t *testing.T) {
+	cache := cbwebtest.NewCache()
+	admin := newMountedModule(t, cache, cbweb.Mount{Prefix: "/admin"}, "")
+	shop := newMountedModule(t, cache, cbweb.Mount{Prefix: "/shop"}, "")
+
+	expected := map[*cbwebcommon.Module]string{
+		admin: "/admin/page /admin/css/main.css?1 //cdn.example.org/lib.js",
+		shop:  "/shop/page /shop/css/main.css?1 //cdn.example.org/lib.js",
+	}
+	// the second render of each comes from the shared cache
+	for _, module := range []*cbwebcommon.Module{admin, shop, admin, shop} {
+		if body := executePage(t, module); body != expected[module] {
+			t.Errorf("expected %q, got %q", expected[module], body)
+		}
+	}
+}
+
+func TestCdnUrlSkipsMountPrefix(t *testing.T) {
+	module := newMountedModule(t, cbwebtest.NewCache(), cbweb.Mount{Prefix: "/admin"}, "https://cdn.example.com/admin-assets/")
+
+	expected := "/admin/page https://cdn.example.com/admin-assets/css/main.css?1 //cdn.example.org/lib.js"
+	if body := executePage(t, module); body != expected {
+		t.Errorf("expected %q, got %q", expected, body)
+	}
+}
@@ -11,18 +11,17 @@ func (m *Module) SetRoutes(routes *router.Router) {
 	routes.GET("/img/{filepath:*}", m.FileServer)
 	routes.GET("/assets/{filepath:*}", m.FileServer)
 	routes.GET("/manifest.json", m.FileServer)
-	routes.GET("/404", cbweb.MiddlewareHandler{}.
-		AddMiddleware(cbweb.HtmlMiddleware).
-		SetFinal(m.FourOFourError).
-		Handle,
-	)
-	routes.GET("/500", cbweb.MiddlewareHandler{}.
-		AddMiddleware(cbweb.HtmlMiddleware).
-		SetFinal(m.FiveHundredError).
-		Handle,
-	)
-	routes.NotFound = cbweb.MiddlewareHandler{}.
-		AddMiddleware(cbweb.HtmlMiddleware).
-		SetFinal(m.FourOFourError).
-		Handle
+
+	html := m.getMiddlewareStacks().Group(routes, "", cbweb.MiddlewareStack_Html)
+	html.GET("/404", m.FourOFourError)
+	html.GET("/500", m.FiveHundredError)
+	routes.NotFound = html.Handler(m.FourOFourError)
+}
+
+func (m *Module) getMiddlewareStacks() *cbweb.MiddlewareStacks {
+	if m.MiddlewareStacks != nil {
+		return m.MiddlewareStacks
+	}
+
+	return cbweb.DefaultMiddlewareStacks
 }
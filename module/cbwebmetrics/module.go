@@ -0,0 +1,53 @@
+package cbwebmetrics
+
+import (
+	"errors"
+	"github.com/codingbeard/cbweb"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+)
+
+// Module serves cbweb.Metrics in the prometheus text exposition format, it defaults to the server's
+// Dependencies.Metrics. Mount it on a private listener or protect it with Middleware
+type Module struct {
+	Metrics    *cbweb.Metrics
+	Path       string
+	Middleware []func(ctx *fasthttp.RequestCtx) (bool, error)
+}
+
+func (m *Module) SetDefaults() {
+	if m.Path == "" {
+		m.Path = "/metrics"
+	}
+}
+
+// Init uses the server's Dependencies.Metrics when Metrics is not set, a new Metrics would never be fed any requests
+func (m *Module) Init(server *cbweb.Server) error {
+	if m.Metrics == nil {
+		m.Metrics = server.GetMetrics()
+	}
+	if m.Metrics == nil {
+		return errors.New("cbwebmetrics needs Metrics or the server's Dependencies.Metrics to be set")
+	}
+
+	return nil
+}
+
+func (m *Module) GetModuleName() string {
+	return "cbwebmetrics"
+}
+
+func (m *Module) SetRoutes(routes *router.Router) {
+	m.SetDefaults()
+	routes.GET(m.Path, cbweb.MiddlewareHandler{}.
+		AddMiddleware(m.Middleware...).
+		SetFinal(m.Metrics.Handler).
+		Handle,
+	)
+}
+
+func (m *Module) GetGlobalTemplates() map[string][]byte {
+	return map[string][]byte{}
+}
+
+func (m *Module) SetGlobalTemplates(templates map[string][]byte) {}
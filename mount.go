@@ -0,0 +1,144 @@
+package cbweb
+
+import (
+	"bytes"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"net"
+	"sort"
+	"strings"
+)
+
+var mountPrefixContextKey = NewContextKey("mountPrefix")
+
+// Mount scopes a module to a virtual host and/or a path prefix, an empty Host matches any host
+type Mount struct {
+	Host   string
+	Prefix string
+}
+
+type MountAware interface {
+	SetMount(mount Mount)
+}
+
+type mountRouter struct {
+	mount  Mount
+	routes *router.Router
+}
+
+func (m Mount) normalise() Mount {
+	m.Host = strings.ToLower(strings.TrimSpace(m.Host))
+	m.Prefix = strings.TrimRight(strings.TrimSpace(m.Prefix), "/")
+	if m.Prefix != "" && !strings.HasPrefix(m.Prefix, "/") {
+		m.Prefix = "/" + m.Prefix
+	}
+
+	return m
+}
+
+func (m Mount) IsRoot() bool {
+	return m.Host == "" && m.Prefix == ""
+}
+
+func (m Mount) String() string {
+	if m.IsRoot() {
+		return "/"
+	}
+
+	return m.Host + m.Prefix
+}
+
+func (m Mount) matches(host string, path []byte) bool {
+	if m.Host != "" && m.Host != host {
+		return false
+	}
+	if m.Prefix == "" {
+		return true
+	}
+	if !bytes.HasPrefix(path, []byte(m.Prefix)) {
+		return false
+	}
+
+	return len(path) == len(m.Prefix) || path[len(m.Prefix)] == '/'
+}
+
+func (s *Server) Mount(mount Mount, modules ...Module) {
+	mount = mount.normalise()
+	for _, module := range modules {
+		if aware, ok := module.(MountAware); ok {
+			aware.SetMount(mount)
+		}
+		s.modules = append(s.modules, module)
+		s.moduleMounts = append(s.moduleMounts, mount)
+	}
+}
+
+// GetMountPrefix returns the path prefix of the mount that is handling the request
+func GetMountPrefix(ctx *fasthttp.RequestCtx) string {
+	if prefix, ok := mountPrefixContextKey.Get(ctx).(string); ok {
+		return prefix
+	}
+
+	return ""
+}
+
+// MountPath prefixes an absolute path with the prefix of the mount that is handling the request
+func MountPath(ctx *fasthttp.RequestCtx, path string) string {
+	if strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//") {
+		return GetMountPrefix(ctx) + path
+	}
+
+	return path
+}
+
+func newRouter() *router.Router {
+	routes := router.New()
+	routes.RedirectTrailingSlash = false
+	routes.RedirectFixedPath = false
+	routes.SaveMatchedRoutePath = true
+
+	return routes
+}
+
+// mountHandler dispatches to the most specific matching mount, host mounts win over hostless ones
+// and longer prefixes win over shorter ones. The mount prefix is stripped from the path before routing.
+func mountHandler(root *router.Router, mounted []mountRouter) fasthttp.RequestHandler {
+	sort.SliceStable(mounted, func(i, j int) bool {
+		if (mounted[i].mount.Host != "") != (mounted[j].mount.Host != "") {
+			return mounted[i].mount.Host != ""
+		}
+		return len(mounted[i].mount.Prefix) > len(mounted[j].mount.Prefix)
+	})
+
+	return func(ctx *fasthttp.RequestCtx) {
+		if len(mounted) == 0 {
+			root.Handler(ctx)
+			return
+		}
+
+		host := string(ctx.Host())
+		if hostname, _, e := net.SplitHostPort(host); e == nil {
+			host = hostname
+		}
+		host = strings.ToLower(host)
+
+		path := ctx.Path()
+		for _, mount := range mounted {
+			if !mount.mount.matches(host, path) {
+				continue
+			}
+			if mount.mount.Prefix != "" {
+				mountPrefixContextKey.Set(ctx, mount.mount.Prefix)
+				stripped := path[len(mount.mount.Prefix):]
+				if len(stripped) == 0 {
+					stripped = []byte("/")
+				}
+				ctx.URI().SetPathBytes(append([]byte(nil), stripped...))
+			}
+			mount.routes.Handler(ctx)
+			return
+		}
+
+		root.Handler(ctx)
+	}
+}
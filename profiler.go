@@ -0,0 +1,111 @@
+package cbweb
+
+import (
+	"fmt"
+	"github.com/valyala/fasthttp"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var (
+	ServerTimingHeader = "Server-Timing"
+	profileContextKey  = NewContextKey("profile")
+)
+
+// Profiler is started once per request by the outermost MiddlewareHandler it is set on,
+// End is always called with the spans recorded during the request, even when the chain halted or panicked
+type Profiler interface {
+	Start(ctx *fasthttp.RequestCtx)
+	End(ctx *fasthttp.RequestCtx, profile Profile)
+}
+
+type Profile struct {
+	Start    time.Time
+	Duration time.Duration
+	Spans    []Timing
+}
+
+// DefaultProfiler passes each profile to Sink and optionally writes it to the Server-Timing header,
+// the header exposes handler names so should only be enabled in dev
+type DefaultProfiler struct {
+	ServerTiming bool
+	Sink         func(ctx *fasthttp.RequestCtx, profile Profile)
+}
+
+func (d DefaultProfiler) Start(ctx *fasthttp.RequestCtx) {}
+
+func (d DefaultProfiler) End(ctx *fasthttp.RequestCtx, profile Profile) {
+	if d.ServerTiming {
+		ctx.Response.Header.Set(ServerTimingHeader, FormatServerTiming(profile))
+	}
+	if d.Sink != nil {
+		d.Sink(ctx, profile)
+	}
+}
+
+func startProfile(ctx *fasthttp.RequestCtx, profiler Profiler) func() {
+	if profiler == nil || profileContextKey.Has(ctx) {
+		return func() {}
+	}
+
+	start := time.Now()
+	profileContextKey.Set(ctx, start)
+	profiler.Start(ctx)
+
+	return func() {
+		profiler.End(ctx, Profile{
+			Start:    start,
+			Duration: time.Since(start),
+			Spans:    GetTimings(ctx),
+		})
+	}
+}
+
+func IsProfiling(ctx *fasthttp.RequestCtx) bool {
+	return profileContextKey.Has(ctx)
+}
+
+// StartSpan times part of a request when it is being profiled, call the returned func to end the span
+func StartSpan(ctx *fasthttp.RequestCtx, name string) func() {
+	if !IsProfiling(ctx) {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() {
+		AddTiming(ctx, name, time.Since(start))
+	}
+}
+
+func FormatServerTiming(profile Profile) string {
+	var metrics []string
+	for key, span := range profile.Spans {
+		metrics = append(metrics, fmt.Sprintf(
+			`%d;desc="%s";dur=%.3f`,
+			key,
+			strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(span.Name),
+			float64(span.Duration)/float64(time.Millisecond),
+		))
+	}
+	metrics = append(metrics, fmt.Sprintf("total;dur=%.3f", float64(profile.Duration)/float64(time.Millisecond)))
+
+	return strings.Join(metrics, ", ")
+}
+
+// getHandlerName returns the package qualified name of a handler func for span names
+func getHandlerName(handler interface{}) string {
+	function := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if function == nil {
+		return "unknown"
+	}
+
+	name := function.Name()
+	if index := strings.LastIndex(name, "/"); index != -1 {
+		name = name[index+1:]
+	}
+
+	return name
+}
@@ -0,0 +1,277 @@
+package cbweb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/valyala/fasthttp"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	rateLimitDefaultMessage  = "Error: 429 Too Many Requests"
+)
+
+// RateLimitKeyFunc identifies who a request is counted against, an empty key falls back to the client ip
+type RateLimitKeyFunc func(ctx *fasthttp.RequestCtx) string
+
+type RateLimitQuota struct {
+	// Permissions selects the quota when the user has any of them, checked the same way as cbwebauth.Acl
+	Permissions []string
+	Max         int64
+	Ttl         time.Duration
+}
+
+type RateLimitConfig struct {
+	// Max requests per Ttl for requests which do not match a quota, defaults to 1 per second
+	Max int64
+	Ttl time.Duration
+	// Store defaults to a MemoryRateLimitStore, share one such as cbwebredis.RateLimitStore between replicas
+	Store RateLimitStore
+	// Algorithm defaults to RateLimitAlgorithm_TokenBucket
+	Algorithm RateLimitAlgorithm
+	// Name separates the counts of limiters using the same store, defaults to default
+	Name string
+	// KeyFunc defaults to the client ip
+	KeyFunc RateLimitKeyFunc
+	// Quotas are checked in order and the first the user is permitted is used, they need Auth
+	Quotas  []RateLimitQuota
+	Auth    *cbwebauth.Container
+	Methods []string
+	// TrustedProxies are believed about the client's ip when counting by ip
+	TrustedProxies *TrustedProxies
+	// ContentType forces the content type of the 429 response, by default it is html or json to match
+	// the route, falling back to the Accept header
+	ContentType string
+	Message     string
+	// HtmlPage renders the 429 response for html routes, pass a module's error page to keep the site's look
+	HtmlPage func(ctx *fasthttp.RequestCtx)
+}
+
+type rateLimitQuota struct {
+	permissions []string
+	max         int64
+	ttl         time.Duration
+}
+
+// RateLimiter is middleware which can be added to any MiddlewareHandler or RouteGroup, unlike
+// MiddlewareHandler.Limiter it can count by user or api key and give permissions their own quota
+type RateLimiter struct {
+	config   RateLimitConfig
+	methods  map[string]bool
+	acl      *cbwebauth.Acl
+	fallback rateLimitQuota
+	quotas   []rateLimitQuota
+	prefix   string
+}
+
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	if config.Max == 0 {
+		config.Max = 1
+	}
+	if config.Ttl == 0 {
+		config.Ttl = time.Second
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryRateLimitStore()
+	}
+	if config.Algorithm == "" {
+		config.Algorithm = RateLimitAlgorithm_TokenBucket
+	}
+	if config.Name == "" {
+		config.Name = "default"
+	}
+	if config.Message == "" {
+		config.Message = rateLimitDefaultMessage
+	}
+
+	methods := make(map[string]bool)
+	for _, method := range config.Methods {
+		methods[method] = true
+	}
+
+	r := &RateLimiter{
+		config:   config,
+		methods:  methods,
+		fallback: rateLimitQuota{max: config.Max, ttl: config.Ttl},
+		prefix:   "cbweb-rate-limit:" + config.Name + ":",
+	}
+	if config.Auth != nil {
+		r.acl = &cbwebauth.Acl{Auth: config.Auth}
+		for _, quota := range config.Quotas {
+			if quota.Max == 0 {
+				quota.Max = config.Max
+			}
+			if quota.Ttl == 0 {
+				quota.Ttl = config.Ttl
+			}
+			r.quotas = append(r.quotas, rateLimitQuota{
+				permissions: quota.Permissions,
+				max:         quota.Max,
+				ttl:         quota.Ttl,
+			})
+		}
+	}
+
+	return r
+}
+
+func (r *RateLimiter) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if len(r.methods) > 0 && !r.methods[string(ctx.Method())] {
+		return true, nil
+	}
+
+	quota := r.getQuota(ctx)
+	result, e := r.config.Algorithm.Take(r.config.Store, r.prefix+r.GetKey(ctx), quota.max, quota.ttl, time.Now())
+	if e != nil {
+		// fail open, an unavailable store should not take the site down with it
+		return true, e
+	}
+	SetRateLimitHeaders(ctx, result)
+	if result.Allowed {
+		return true, nil
+	}
+
+	writeRateLimitRejection(ctx, result, fasthttp.StatusTooManyRequests, r.config.ContentType, r.config.Message, r.config.HtmlPage)
+
+	return false, nil
+}
+
+// GetKey returns the key the request is counted against
+func (r *RateLimiter) GetKey(ctx *fasthttp.RequestCtx) string {
+	if r.config.KeyFunc != nil {
+		if key := r.config.KeyFunc(ctx); key != "" {
+			return key
+		}
+	}
+
+	return "ip:" + GetRemoteIp(ctx, r.config.TrustedProxies)
+}
+
+func (r *RateLimiter) getQuota(ctx *fasthttp.RequestCtx) rateLimitQuota {
+	for _, quota := range r.quotas {
+		if r.acl.PermittedCtx(ctx, quota.permissions) {
+			return quota
+		}
+	}
+
+	return r.fallback
+}
+
+func RateLimitByIp(proxies *TrustedProxies) RateLimitKeyFunc {
+	return func(ctx *fasthttp.RequestCtx) string {
+		return "ip:" + GetRemoteIp(ctx, proxies)
+	}
+}
+
+// RateLimitByUser counts authenticated requests against the user's unique identifier
+func RateLimitByUser(auth *cbwebauth.Container) RateLimitKeyFunc {
+	return func(ctx *fasthttp.RequestCtx) string {
+		identifier := auth.FindUniqueIdentifier(ctx)
+		if identifier == "" {
+			return ""
+		}
+
+		return "user:" + identifier
+	}
+}
+
+// RateLimitByApiKey counts requests against the api key in the header, or the query arg when it is set.
+// The key is hashed so it never ends up in a store
+func RateLimitByApiKey(headerName, queryArg string) RateLimitKeyFunc {
+	return func(ctx *fasthttp.RequestCtx) string {
+		apiKey := ctx.Request.Header.Peek(headerName)
+		if len(apiKey) == 0 && queryArg != "" {
+			apiKey = ctx.QueryArgs().Peek(queryArg)
+		}
+		if len(apiKey) == 0 {
+			return ""
+		}
+
+		hash := sha256.Sum256(apiKey)
+
+		return "api-key:" + hex.EncodeToString(hash[:16])
+	}
+}
+
+// SetRateLimitHeaders sets the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers,
+// and Retry-After when the request was rejected. Durations are whole seconds, rounded up
+func SetRateLimitHeaders(ctx *fasthttp.RequestCtx, result RateLimitResult) {
+	ctx.Response.Header.Set(RateLimitLimitHeader, strconv.FormatInt(result.Limit, 10))
+	ctx.Response.Header.Set(RateLimitRemainingHeader, strconv.FormatInt(result.Remaining, 10))
+	ctx.Response.Header.Set(RateLimitResetHeader, strconv.FormatInt(formatRateLimitSeconds(result.Reset), 10))
+	if !result.Allowed {
+		retryAfter := formatRateLimitSeconds(result.RetryAfter)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, strconv.FormatInt(retryAfter, 10))
+	}
+}
+
+func writeRateLimitRejection(ctx *fasthttp.RequestCtx, result RateLimitResult, status int, contentType, message string, htmlPage func(ctx *fasthttp.RequestCtx)) {
+	recordRateLimited(ctx)
+
+	if message == "" {
+		message = rateLimitDefaultMessage
+	}
+	if contentType == "" {
+		contentType = getRateLimitContentType(ctx)
+	}
+	retryAfter, _ := strconv.ParseInt(string(ctx.Response.Header.Peek(fasthttp.HeaderRetryAfter)), 10, 64)
+
+	ctx.SetStatusCode(status)
+	switch {
+	case strings.Contains(contentType, "json"):
+		body, _ := json.Marshal(map[string]interface{}{
+			"error":       message,
+			"retry_after": retryAfter,
+		})
+		ctx.SetContentType(contentType)
+		ctx.SetBody(body)
+	case strings.Contains(contentType, "html") && htmlPage != nil:
+		htmlPage(ctx)
+		ctx.SetStatusCode(status)
+	case strings.Contains(contentType, "html"):
+		ctx.SetContentType(contentType)
+		ctx.SetBodyString("<!DOCTYPE html><html><head><title>" + template.HTMLEscapeString(message) + "</title></head><body><h1>" +
+			template.HTMLEscapeString(message) + "</h1><p>Please try again in " + strconv.FormatInt(retryAfter, 10) + " seconds.</p></body></html>")
+	default:
+		ctx.SetContentType(contentType)
+		ctx.SetBodyString(message)
+	}
+}
+
+// getRateLimitContentType follows the content type set by HtmlMiddleware or JsonMiddleware,
+// then the Accept header when the route has not set one yet
+func getRateLimitContentType(ctx *fasthttp.RequestCtx) string {
+	routeType := string(ctx.Response.Header.ContentType())
+	if strings.Contains(routeType, "json") || strings.Contains(routeType, "html") {
+		return routeType
+	}
+
+	accept := string(ctx.Request.Header.Peek(fasthttp.HeaderAccept))
+	if strings.Contains(accept, "json") && !strings.Contains(accept, "html") {
+		return "application/json"
+	}
+	if strings.Contains(accept, "html") {
+		return "text/html; charset=utf-8"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+func formatRateLimitSeconds(duration time.Duration) int64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return int64((duration + time.Second - 1) / time.Second)
+}
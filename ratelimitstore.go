@@ -0,0 +1,287 @@
+package cbweb
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type RateLimitAlgorithm string
+
+var (
+	// RateLimitAlgorithm_FixedWindow counts requests in windows aligned to the Ttl, it allows bursts of up to
+	// double the Max across a window boundary
+	RateLimitAlgorithm_FixedWindow RateLimitAlgorithm = "fixed-window"
+	// RateLimitAlgorithm_SlidingWindow weights the previous window's count by how much of it still overlaps
+	RateLimitAlgorithm_SlidingWindow RateLimitAlgorithm = "sliding-window"
+	// RateLimitAlgorithm_TokenBucket refills Max tokens evenly over the Ttl, stored as a theoretical arrival time
+	RateLimitAlgorithm_TokenBucket RateLimitAlgorithm = "token-bucket"
+
+	rateLimitCasAttempts = 50
+)
+
+// RateLimitStore holds rate limit state so every replica counts against the same limits.
+// Implementations must make Increment and CompareAndSwap atomic across every client of the store
+type RateLimitStore interface {
+	// Increment adds one to the counter at key, creating it to expire after ttl, and returns the new count
+	Increment(key string, ttl time.Duration) (int64, error)
+	// Get returns an empty string when the key does not exist
+	Get(key string) (string, error)
+	// CompareAndSwap sets key to value when it still holds old, an empty old means the key must not exist
+	CompareAndSwap(key, old, value string, ttl time.Duration) (bool, error)
+}
+
+// RateLimitResult describes the state of a key's limit after a request was counted
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// Reset is how long until the limit is fully available again
+	Reset time.Duration
+	// RetryAfter is how long until a rejected request would be allowed
+	RetryAfter time.Duration
+}
+
+// Take counts a request against key in the store
+func (a RateLimitAlgorithm) Take(store RateLimitStore, key string, max int64, window time.Duration, now time.Time) (RateLimitResult, error) {
+	if max <= 0 || window <= 0 {
+		return RateLimitResult{Allowed: true, Limit: max}, errors.New("rate limit max and window must be positive")
+	}
+
+	switch a {
+	case RateLimitAlgorithm_FixedWindow:
+		return takeFixedWindow(store, key, max, window, now)
+	case RateLimitAlgorithm_SlidingWindow:
+		return takeSlidingWindow(store, key, max, window, now)
+	case RateLimitAlgorithm_TokenBucket:
+		return takeTokenBucket(store, key, max, window, now)
+	}
+
+	return RateLimitResult{Allowed: true, Limit: max, Remaining: max}, errors.New("unknown rate limit algorithm " + string(a))
+}
+
+func takeFixedWindow(store RateLimitStore, key string, max int64, window time.Duration, now time.Time) (RateLimitResult, error) {
+	index := now.UnixNano() / int64(window)
+	reset := time.Duration((index+1)*int64(window) - now.UnixNano())
+
+	count, e := store.Increment(key+":"+strconv.FormatInt(index, 10), reset)
+	if e != nil {
+		return RateLimitResult{Allowed: true, Limit: max, Remaining: max}, e
+	}
+
+	result := RateLimitResult{
+		Allowed:   count <= max,
+		Limit:     max,
+		Remaining: max - count,
+		Reset:     reset,
+	}
+	if !result.Allowed {
+		result.RetryAfter = reset
+	}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	return result, nil
+}
+
+func takeSlidingWindow(store RateLimitStore, key string, max int64, window time.Duration, now time.Time) (RateLimitResult, error) {
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - index*int64(window))
+	failed := RateLimitResult{Allowed: true, Limit: max, Remaining: max}
+
+	previousValue, e := store.Get(key + ":" + strconv.FormatInt(index-1, 10))
+	if e != nil {
+		return failed, e
+	}
+	previous := int64(0)
+	if previousValue != "" {
+		previous, e = strconv.ParseInt(previousValue, 10, 64)
+		if e != nil {
+			return failed, e
+		}
+	}
+
+	// the current window is kept for the next one to weigh
+	current, e := store.Increment(key+":"+strconv.FormatInt(index, 10), 2*window-elapsed)
+	if e != nil {
+		return failed, e
+	}
+
+	overlap := float64(window-elapsed) / float64(window)
+	estimate := int64(float64(previous)*overlap) + current
+
+	result := RateLimitResult{
+		Allowed:   estimate <= max,
+		Limit:     max,
+		Remaining: max - estimate,
+		Reset:     2*window - elapsed,
+	}
+	if !result.Allowed {
+		result.RetryAfter = window - elapsed
+		if previous > 0 && current < max {
+			// the previous window's weight falls until the estimate is back under max
+			wait := window - elapsed - time.Duration(float64(max-current)*float64(window)/float64(previous))
+			if wait > 0 && wait < result.RetryAfter {
+				result.RetryAfter = wait
+			}
+		}
+	}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	return result, nil
+}
+
+// takeTokenBucket implements the token bucket as the generic cell rate algorithm, which stores only the
+// theoretical arrival time of the next request so it can be updated with a single compare and swap
+func takeTokenBucket(store RateLimitStore, key string, max int64, window time.Duration, now time.Time) (RateLimitResult, error) {
+	interval := window / time.Duration(max)
+	if interval < 1 {
+		// more than one request per nanosecond, the bucket can only refill a token each nanosecond
+		interval = 1
+	}
+	failed := RateLimitResult{Allowed: true, Limit: max, Remaining: max}
+
+	for attempt := 0; attempt < rateLimitCasAttempts; attempt++ {
+		stored, e := store.Get(key)
+		if e != nil {
+			return failed, e
+		}
+
+		arrival := now
+		if stored != "" {
+			nanos, e := strconv.ParseInt(stored, 10, 64)
+			if e != nil {
+				return failed, e
+			}
+			if storedArrival := time.Unix(0, nanos); storedArrival.After(now) {
+				arrival = storedArrival
+			}
+		}
+
+		next := arrival.Add(interval)
+		allowAt := next.Add(-window)
+		if now.Before(allowAt) {
+			return RateLimitResult{
+				Allowed:    false,
+				Limit:      max,
+				Remaining:  0,
+				Reset:      arrival.Sub(now),
+				RetryAfter: allowAt.Sub(now),
+			}, nil
+		}
+
+		swapped, e := store.CompareAndSwap(key, stored, strconv.FormatInt(next.UnixNano(), 10), next.Sub(now))
+		if e != nil {
+			return failed, e
+		}
+		if swapped {
+			return RateLimitResult{
+				Allowed:   true,
+				Limit:     max,
+				Remaining: int64((window - next.Sub(now)) / interval),
+				Reset:     next.Sub(now),
+			}, nil
+		}
+	}
+
+	// every attempt lost to another request taking a token, a key this contended is being hammered
+	return RateLimitResult{
+		Allowed:    false,
+		Limit:      max,
+		Remaining:  0,
+		Reset:      window,
+		RetryAfter: interval,
+	}, nil
+}
+
+type memoryRateLimitValue struct {
+	value   string
+	expires time.Time
+}
+
+// MemoryRateLimitStore keeps rate limit state in process, use it for a single replica or as a fallback
+type MemoryRateLimitStore struct {
+	values map[string]memoryRateLimitValue
+	writes int
+	lock   sync.Mutex
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{values: make(map[string]memoryRateLimitValue)}
+}
+
+func (s *MemoryRateLimitStore) Increment(key string, ttl time.Duration) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	current, ok := s.get(key, now)
+	if !ok {
+		current = memoryRateLimitValue{value: "0", expires: now.Add(ttl)}
+	}
+	count, e := strconv.ParseInt(current.value, 10, 64)
+	if e != nil {
+		return 0, errors.New("rate limit key " + key + " does not hold a counter")
+	}
+	count++
+	current.value = strconv.FormatInt(count, 10)
+	s.set(key, current, now)
+
+	return count, nil
+}
+
+func (s *MemoryRateLimitStore) Get(key string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current, _ := s.get(key, time.Now())
+
+	return current.value, nil
+}
+
+func (s *MemoryRateLimitStore) CompareAndSwap(key, old, value string, ttl time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	current, _ := s.get(key, now)
+	if current.value != old {
+		return false, nil
+	}
+	s.set(key, memoryRateLimitValue{value: value, expires: now.Add(ttl)}, now)
+
+	return true, nil
+}
+
+func (s *MemoryRateLimitStore) get(key string, now time.Time) (memoryRateLimitValue, bool) {
+	current, ok := s.values[key]
+	if !ok {
+		return memoryRateLimitValue{}, false
+	}
+	if !current.expires.After(now) {
+		delete(s.values, key)
+		return memoryRateLimitValue{}, false
+	}
+
+	return current, true
+}
+
+func (s *MemoryRateLimitStore) set(key string, value memoryRateLimitValue, now time.Time) {
+	s.values[key] = value
+
+	// expired keys are only removed when read, sweep every so often so abandoned keys do not pile up
+	s.writes++
+	if s.writes < 1000 {
+		return
+	}
+	s.writes = 0
+	for key, value := range s.values {
+		if !value.expires.After(now) {
+			delete(s.values, key)
+		}
+	}
+}
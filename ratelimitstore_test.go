@@ -0,0 +1,149 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebredis"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type rateLimitStep struct {
+	offset  time.Duration
+	takes   int
+	allowed int
+}
+
+// the windows are aligned to 10 seconds, so offsets are from the start of a window
+var rateLimitBase = time.Unix(1700000000, 0)
+
+func getRateLimitStores(t *testing.T) map[string]cbweb.RateLimitStore {
+	redis := cbwebtest.NewRedisServer(t)
+	client := cbwebredis.New(cbwebredis.Config{Address: redis.Address})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return map[string]cbweb.RateLimitStore{
+		"memory": cbweb.NewMemoryRateLimitStore(),
+		"redis":  cbwebredis.NewRateLimitStore(client),
+	}
+}
+
+func TestRateLimitAlgorithms(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm cbweb.RateLimitAlgorithm
+		steps     []rateLimitStep
+	}{
+		{
+			name:      "fixed window allows a burst across the boundary",
+			algorithm: cbweb.RateLimitAlgorithm_FixedWindow,
+			steps:     []rateLimitStep{{offset: time.Millisecond * 9990, takes: 6, allowed: 5}, {offset: time.Second * 10, takes: 6, allowed: 5}},
+		},
+		{
+			name:      "fixed window refills in the next window",
+			algorithm: cbweb.RateLimitAlgorithm_FixedWindow,
+			steps:     []rateLimitStep{{offset: 0, takes: 6, allowed: 5}, {offset: time.Second * 5, takes: 1, allowed: 0}, {offset: time.Second * 10, takes: 6, allowed: 5}},
+		},
+		{
+			name:      "sliding window weighs the previous window at the boundary",
+			algorithm: cbweb.RateLimitAlgorithm_SlidingWindow,
+			steps:     []rateLimitStep{{offset: time.Millisecond * 9990, takes: 5, allowed: 5}, {offset: time.Millisecond * 10010, takes: 5, allowed: 1}},
+		},
+		{
+			name:      "sliding window refills as the previous window falls away",
+			algorithm: cbweb.RateLimitAlgorithm_SlidingWindow,
+			steps:     []rateLimitStep{{offset: 0, takes: 5, allowed: 5}, {offset: time.Second * 15, takes: 5, allowed: 3}, {offset: time.Second * 30, takes: 6, allowed: 5}},
+		},
+		{
+			name:      "token bucket prevents a burst across the boundary",
+			algorithm: cbweb.RateLimitAlgorithm_TokenBucket,
+			steps:     []rateLimitStep{{offset: time.Millisecond * 9990, takes: 5, allowed: 5}, {offset: time.Second * 10, takes: 5, allowed: 0}},
+		},
+		{
+			name:      "token bucket refills evenly",
+			algorithm: cbweb.RateLimitAlgorithm_TokenBucket,
+			steps:     []rateLimitStep{{offset: 0, takes: 6, allowed: 5}, {offset: time.Second * 4, takes: 5, allowed: 2}, {offset: time.Second * 20, takes: 6, allowed: 5}},
+		},
+	}
+
+	for storeName, store := range getRateLimitStores(t) {
+		for i, test := range tests {
+			t.Run(storeName+"/"+test.name, func(t *testing.T) {
+				key := "test:" + strconv.Itoa(i)
+				for _, step := range test.steps {
+					allowed := 0
+					for take := 0; take < step.takes; take++ {
+						result, e := test.algorithm.Take(store, key, 5, time.Second*10, rateLimitBase.Add(step.offset))
+						if e != nil {
+							t.Fatal(e)
+						}
+						if result.Allowed {
+							allowed++
+						} else if result.RetryAfter <= 0 {
+							t.Errorf("rejection at %s has no RetryAfter", step.offset)
+						}
+					}
+					if allowed != step.allowed {
+						t.Errorf("at %s allowed %d of %d, expected %d", step.offset, allowed, step.takes, step.allowed)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimitAlgorithmsConcurrent(t *testing.T) {
+	algorithms := []cbweb.RateLimitAlgorithm{
+		cbweb.RateLimitAlgorithm_FixedWindow,
+		cbweb.RateLimitAlgorithm_SlidingWindow,
+		cbweb.RateLimitAlgorithm_TokenBucket,
+	}
+
+	for storeName, store := range getRateLimitStores(t) {
+		for _, algorithm := range algorithms {
+			t.Run(storeName+"/"+string(algorithm), func(t *testing.T) {
+				var allowed int32
+				var wg sync.WaitGroup
+				for take := 0; take < 40; take++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						result, e := algorithm.Take(store, "concurrent:"+string(algorithm), 10, time.Second*10, rateLimitBase.Add(time.Second))
+						if e != nil {
+							t.Error(e)
+							return
+						}
+						if result.Allowed {
+							atomic.AddInt32(&allowed, 1)
+						}
+					}()
+				}
+				wg.Wait()
+
+				if allowed != 10 {
+					t.Errorf("allowed %d of 40 concurrent takes, expected 10", allowed)
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimitTokenBucketMaxAboveWindow(t *testing.T) {
+	for storeName, store := range getRateLimitStores(t) {
+		t.Run(storeName, func(t *testing.T) {
+			// more requests than nanoseconds in the window, the refill interval rounds down to zero
+			result, e := cbweb.RateLimitAlgorithm_TokenBucket.Take(store, "tiny-window", 1000, time.Nanosecond*100, rateLimitBase)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if !result.Allowed {
+				t.Errorf("expected the first take to be allowed, got %+v", result)
+			}
+		})
+	}
+}
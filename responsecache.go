@@ -0,0 +1,350 @@
+package cbweb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/codingbeard/cbweb/cbwebauth"
+	"github.com/valyala/fasthttp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ResponseCacheHeader = "X-Cache"
+	// the per request csrf token and csp nonce are swapped for these when stored and back when replayed
+	responseCacheCsrfPlaceholder  = []byte("cbweb-response-cache-csrf-token")
+	responseCacheNoncePlaceholder = []byte("cbweb-response-cache-csp-nonce")
+	responseCacheTagTtl           = time.Hour * 24
+	responseCacheSkippedHeaders   = map[string]bool{
+		"Set-Cookie":     true,
+		"Date":           true,
+		"Server":         true,
+		"Connection":     true,
+		"Content-Length": true,
+	}
+	// headers which belong to the request being served, they are neither stored nor replayed
+	responseCacheRequestHeaders = map[string]bool{
+		textproto.CanonicalMIMEHeaderKey(RequestIdHeader):           true,
+		textproto.CanonicalMIMEHeaderKey(ServerTimingHeader):        true,
+		textproto.CanonicalMIMEHeaderKey(ResponseCacheHeader):       true,
+		textproto.CanonicalMIMEHeaderKey(IdempotencyReplayedHeader): true,
+		textproto.CanonicalMIMEHeaderKey(RateLimitLimitHeader):      true,
+		textproto.CanonicalMIMEHeaderKey(RateLimitRemainingHeader):  true,
+		textproto.CanonicalMIMEHeaderKey(RateLimitResetHeader):      true,
+		textproto.CanonicalMIMEHeaderKey(fasthttp.HeaderRetryAfter): true,
+		"Content-Security-Policy":                                   true,
+		"Content-Security-Policy-Report-Only":                       true,
+		"Access-Control-Allow-Origin":                               true,
+		"Access-Control-Allow-Credentials":                          true,
+	}
+)
+
+type ResponseCacheConfig struct {
+	Cache CacheProvider
+	// Ttl defaults to one minute
+	Ttl       time.Duration
+	KeyPrefix string
+	// QueryArgs selects the query args which are part of the key, by default every arg is
+	QueryArgs   []string
+	VaryHeaders []string
+	// Auth is used to bypass the cache for authenticated users. Without it any request with a cookie or
+	// Authorization header bypasses the cache, as it cannot be told apart from a logged in user's
+	Auth *cbwebauth.Container
+	// IgnoredCookies neither bypass the cache when sent nor stop a response being stored when set, the csrf
+	// cookie always is as the token is swapped in to replayed pages
+	IgnoredCookies []string
+	// CacheAuthenticated caches responses for authenticated users too, keyed by their identifier
+	CacheAuthenticated bool
+	Tags               []string
+	TagsFunc           func(ctx *fasthttp.RequestCtx) []string
+	// Statuses which are stored, defaults to 200
+	Statuses []int
+}
+
+// CachedResponse is what is stored in the CacheProvider
+type CachedResponse struct {
+	Status  int
+	Headers [][2]string
+	Body    []byte
+	Tags    map[string]int64
+	Created time.Time
+}
+
+// ResponseCache stores whole responses for GET and HEAD requests. Responses which set cookies other than
+// the csrf cookie and IgnoredCookies, or Cache-Control private or no-store, are never stored
+type ResponseCache struct {
+	config         ResponseCacheConfig
+	statuses       map[int]bool
+	ignoredCookies map[string]bool
+}
+
+func NewResponseCache(config ResponseCacheConfig) *ResponseCache {
+	if config.Ttl == 0 {
+		config.Ttl = time.Minute
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "cbweb-response-cache:"
+	}
+	if len(config.Statuses) == 0 {
+		config.Statuses = []int{fasthttp.StatusOK}
+	}
+
+	statuses := make(map[int]bool)
+	for _, status := range config.Statuses {
+		statuses[status] = true
+	}
+
+	ignoredCookies := make(map[string]bool)
+	for _, name := range config.IgnoredCookies {
+		ignoredCookies[name] = true
+	}
+
+	return &ResponseCache{config: config, statuses: statuses, ignoredCookies: ignoredCookies}
+}
+
+// Wrap returns a final handler which replays a cached response or runs final and stores what it produced,
+// after final handlers such as compression run either way
+func (c *ResponseCache) Wrap(final func(ctx *fasthttp.RequestCtx)) func(ctx *fasthttp.RequestCtx) {
+	return func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() && !ctx.IsHead() {
+			final(ctx)
+			return
+		}
+
+		identifier := ""
+		if c.config.Auth != nil {
+			identifier = c.config.Auth.FindUniqueIdentifier(ctx)
+			if identifier != "" && !c.config.CacheAuthenticated {
+				final(ctx)
+				return
+			}
+		} else if c.hasUserCookie(ctx) || len(ctx.Request.Header.Peek(fasthttp.HeaderAuthorization)) > 0 {
+			final(ctx)
+			return
+		}
+
+		key := c.getKey(ctx, identifier)
+		if c.replay(ctx, key) {
+			return
+		}
+
+		final(ctx)
+		ctx.Response.Header.Set(ResponseCacheHeader, "MISS")
+		c.store(ctx, key)
+	}
+}
+
+func (c *ResponseCache) getKey(ctx *fasthttp.RequestCtx, identifier string) string {
+	var key strings.Builder
+	key.Write(ctx.Method())
+	key.WriteString("\n")
+	key.Write(ctx.Host())
+	key.WriteString("\n")
+	key.WriteString(GetMountPrefix(ctx))
+	key.Write(ctx.Path())
+	key.WriteString("\n")
+
+	args := ctx.QueryArgs()
+	if len(c.config.QueryArgs) > 0 {
+		for _, name := range c.config.QueryArgs {
+			for _, value := range args.PeekMulti(name) {
+				key.WriteString(name + "=" + string(value) + "&")
+			}
+		}
+	} else {
+		var pairs []string
+		args.VisitAll(func(name, value []byte) {
+			pairs = append(pairs, string(name)+"="+string(value))
+		})
+		sort.Strings(pairs)
+		key.WriteString(strings.Join(pairs, "&"))
+	}
+	key.WriteString("\n")
+
+	for _, header := range c.config.VaryHeaders {
+		key.WriteString(header + ":" + string(ctx.Request.Header.Peek(header)) + "\n")
+	}
+	key.WriteString(identifier)
+
+	hash := sha256.Sum256([]byte(key.String()))
+
+	return c.config.KeyPrefix + hex.EncodeToString(hash[:])
+}
+
+func (c *ResponseCache) replay(ctx *fasthttp.RequestCtx, key string) bool {
+	value, ok := c.config.Cache.Get(key)
+	if !ok {
+		return false
+	}
+	cached, ok := value.(*CachedResponse)
+	if !ok {
+		return false
+	}
+	for tag, version := range cached.Tags {
+		current, ok := c.getTagVersion(tag)
+		if !ok || current != version {
+			c.config.Cache.Delete(key)
+			return false
+		}
+	}
+
+	ctx.SetStatusCode(cached.Status)
+	setCachedHeaders(ctx, cached.Headers)
+	body := bytes.Replace(cached.Body, responseCacheCsrfPlaceholder, []byte(GetCsrfToken(ctx).Token), -1)
+	body = bytes.Replace(body, responseCacheNoncePlaceholder, []byte(GetCspNonce(ctx)), -1)
+	ctx.SetBody(body)
+	ctx.Response.Header.Set(ResponseCacheHeader, "HIT")
+
+	return true
+}
+
+func (c *ResponseCache) store(ctx *fasthttp.RequestCtx, key string) {
+	if !c.statuses[ctx.Response.StatusCode()] || ctx.Response.IsBodyStream() {
+		return
+	}
+	if c.setsUserCookie(ctx) {
+		return
+	}
+	// the flash cookie is only written in the always phase, after this, so check for messages directly
+	if hasFlashMessages(ctx) {
+		return
+	}
+	cacheControl := strings.ToLower(string(ctx.Response.Header.Peek(fasthttp.HeaderCacheControl)))
+	if strings.Contains(cacheControl, "private") || strings.Contains(cacheControl, "no-store") {
+		return
+	}
+
+	cached := &CachedResponse{
+		Status:  ctx.Response.StatusCode(),
+		Headers: getCachedHeaders(ctx),
+		Tags:    make(map[string]int64),
+		Created: time.Now(),
+	}
+
+	cached.Body = append([]byte(nil), getStableBody(ctx, ctx.Response.Body())...)
+
+	tags := c.config.Tags
+	if c.config.TagsFunc != nil {
+		tags = append(append([]string(nil), tags...), c.config.TagsFunc(ctx)...)
+	}
+	for _, tag := range tags {
+		version, ok := c.getTagVersion(tag)
+		if !ok {
+			// a missing version never matches, so responses are never stored against one
+			version = time.Now().UnixNano()
+			c.config.Cache.Set(c.getTagKey(tag), version, c.getTagTtl())
+		}
+		cached.Tags[tag] = version
+	}
+
+	c.config.Cache.Set(key, cached, c.config.Ttl)
+}
+
+func (c *ResponseCache) hasUserCookie(ctx *fasthttp.RequestCtx) bool {
+	found := false
+	ctx.Request.Header.VisitAllCookie(func(name, value []byte) {
+		found = found || !c.isIgnoredCookie(ctx, string(name))
+	})
+
+	return found
+}
+
+func (c *ResponseCache) setsUserCookie(ctx *fasthttp.RequestCtx) bool {
+	found := false
+	ctx.Response.Header.VisitAllCookie(func(name, value []byte) {
+		found = found || !c.isIgnoredCookie(ctx, string(name))
+	})
+
+	return found
+}
+
+func (c *ResponseCache) isIgnoredCookie(ctx *fasthttp.RequestCtx, name string) bool {
+	return c.ignoredCookies[name] || (name != "" && name == GetCsrfToken(ctx).CookieName)
+}
+
+// InvalidateTag expires every response stored with the tag, responses are checked against
+// the tag's version when replayed so nothing has to be enumerated
+func (c *ResponseCache) InvalidateTag(tag string) {
+	c.config.Cache.Set(c.getTagKey(tag), time.Now().UnixNano(), c.getTagTtl())
+}
+
+// getTagVersion returns false when the version has expired or been evicted, responses stored against it are stale
+func (c *ResponseCache) getTagVersion(tag string) (int64, bool) {
+	value, ok := c.config.Cache.Get(c.getTagKey(tag))
+	if !ok {
+		return 0, false
+	}
+	version, ok := value.(int64)
+	if !ok {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// getTagTtl outlives the responses stored against a version, so they usually expire before it does
+func (c *ResponseCache) getTagTtl() time.Duration {
+	if c.config.Ttl*2 > responseCacheTagTtl {
+		return c.config.Ttl * 2
+	}
+
+	return responseCacheTagTtl
+}
+
+func (c *ResponseCache) getTagKey(tag string) string {
+	return c.config.KeyPrefix + "tag:" + tag
+}
+
+// getStableBody swaps this request's csrf token and csp nonce for placeholders, so the same page gives the
+// same body for every request. The body is returned as is when there is nothing to swap
+func getStableBody(ctx *fasthttp.RequestCtx, body []byte) []byte {
+	if token := GetCsrfToken(ctx).Token; token != "" {
+		body = bytes.Replace(body, []byte(token), responseCacheCsrfPlaceholder, -1)
+	}
+	if nonce := GetCspNonce(ctx); nonce != "" {
+		body = bytes.Replace(body, []byte(nonce), responseCacheNoncePlaceholder, -1)
+	}
+
+	return body
+}
+
+func getCachedHeaders(ctx *fasthttp.RequestCtx) [][2]string {
+	var headers [][2]string
+	ctx.Response.Header.VisitAll(func(name, value []byte) {
+		if !isResponseCacheSkippedHeader(string(name)) {
+			headers = append(headers, [2]string{string(name), string(value)})
+		}
+	})
+
+	return headers
+}
+
+// setCachedHeaders replaces the response's headers with the stored ones, apart from those which belong to this request
+func setCachedHeaders(ctx *fasthttp.RequestCtx, headers [][2]string) {
+	replaced := make(map[string]bool)
+	for _, header := range headers {
+		name := textproto.CanonicalMIMEHeaderKey(header[0])
+		if isResponseCacheSkippedHeader(name) {
+			continue
+		}
+		if replaced[name] {
+			ctx.Response.Header.Add(name, header[1])
+			continue
+		}
+		replaced[name] = true
+		if name == fasthttp.HeaderContentType {
+			ctx.SetContentType(header[1])
+		} else {
+			ctx.Response.Header.Set(name, header[1])
+		}
+	}
+}
+
+func isResponseCacheSkippedHeader(name string) bool {
+	name = textproto.CanonicalMIMEHeaderKey(name)
+
+	return responseCacheSkippedHeaders[name] || responseCacheRequestHeaders[name]
+}
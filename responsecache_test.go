@@ -0,0 +1,77 @@
+package cbweb_test
+
+import (
+	"github.com/codingbeard/cbweb"
+	"github.com/codingbeard/cbweb/cbwebtest"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"testing"
+)
+
+func TestResponseCacheWithCsrf(t *testing.T) {
+	csrf := cbweb.NewCsrf(cbweb.CsrfConfig{})
+	cache := cbweb.NewResponseCache(cbweb.ResponseCacheConfig{Cache: cbwebtest.NewCache()})
+	renders := 0
+
+	h := cbwebtest.New(t, cbweb.Dependencies{}, &routesModule{routes: func(r *router.Router) {
+		r.GET("/form", cbweb.MiddlewareHandler{}.
+			AddMiddleware(csrf.Middleware).
+			SetFinal(cache.Wrap(func(ctx *fasthttp.RequestCtx) {
+				renders++
+				ctx.SetContentType("text/html; charset=utf-8")
+				ctx.SetBodyString(`<form>` + string(cbweb.GetCsrfToken(ctx).Input()) + `</form>`)
+			})).
+			Handle,
+		)
+		r.GET("/session", cbweb.MiddlewareHandler{}.
+			SetFinal(cache.Wrap(func(ctx *fasthttp.RequestCtx) {
+				var cookie fasthttp.Cookie
+				cookie.SetKey("session")
+				cookie.SetValue("abc")
+				ctx.Response.Header.SetCookie(&cookie)
+				ctx.SetBodyString("logged in")
+			})).
+			Handle,
+		)
+	}})
+
+	// the first visit sets the csrf cookie, which must not stop the page being stored
+	h.Get("/form").AssertStatus(fasthttp.StatusOK).AssertHeader(cbweb.ResponseCacheHeader, "MISS")
+	token := h.GetCookie("cbweb-csrf")
+	if token == "" {
+		t.Fatal("expected the csrf cookie to be set")
+	}
+
+	// and sending it back must not bypass the cache
+	h.Get("/form").
+		AssertHeader(cbweb.ResponseCacheHeader, "HIT").
+		AssertBodyContains(`value="` + token + `"`)
+
+	// a new visitor gets their own cookie and token from the stored page
+	h.ClearCookies()
+	h.Get("/form").AssertHeader(cbweb.ResponseCacheHeader, "HIT")
+	newToken := h.GetCookie("cbweb-csrf")
+	if newToken == "" || newToken == token {
+		t.Fatalf("expected the new visitor to get a new csrf token, got %q", newToken)
+	}
+	h.Get("/form").
+		AssertBodyContains(`value="` + newToken + `"`).
+		AssertBodyNotContains(token)
+
+	if renders != 1 {
+		t.Errorf("expected the page to be rendered once, it was rendered %d times", renders)
+	}
+
+	// any other cookie could be a logged in user's
+	h.SetCookie("session", "abc")
+	h.Get("/form").AssertNoHeader(cbweb.ResponseCacheHeader)
+	if renders != 2 {
+		t.Errorf("expected a request with another cookie to bypass the cache")
+	}
+
+	// and responses setting one are never stored
+	h.ClearCookies()
+	h.Get("/session").AssertHeader(cbweb.ResponseCacheHeader, "MISS")
+	h.ClearCookies()
+	h.Get("/session").AssertHeader(cbweb.ResponseCacheHeader, "MISS")
+}
@@ -0,0 +1,137 @@
+package cbweb
+
+import (
+	"fmt"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"sort"
+	"strings"
+)
+
+var RoutesPath = "/_cbweb/routes"
+
+type Route struct {
+	Listener string
+	Method   string
+	Path     string
+	Mount    Mount
+	Module   string
+}
+
+type routeTable struct {
+	routes []Route
+}
+
+func (r *routeTable) find(listener string, mount Mount, method, path string) (Route, bool) {
+	for _, route := range r.routes {
+		if route.Listener == listener && route.Mount == mount && route.Method == method && route.Path == path {
+			return route, true
+		}
+	}
+
+	return Route{}, false
+}
+
+// register calls register against routes and records every route it adds as owned by moduleName.
+// Router panics, such as duplicate registrations, are returned as errors naming the modules involved.
+func (r *routeTable) register(listener, moduleName string, mount Mount, routes *router.Router, register func(routes *router.Router)) (e error) {
+	before := make(map[string]int)
+	for method, paths := range routes.List() {
+		before[method] = len(paths)
+	}
+
+	defer func() {
+		rec := recover()
+
+		var added []Route
+		for method, paths := range routes.List() {
+			for _, path := range paths[before[method]:] {
+				added = append(added, Route{Listener: listener, Method: method, Path: path, Mount: mount, Module: moduleName})
+			}
+		}
+
+		if rec == nil {
+			r.routes = append(r.routes, added...)
+			return
+		}
+
+		for key, route := range added {
+			if existing, ok := r.find(listener, mount, route.Method, route.Path); ok {
+				e = fmt.Errorf("module %s: route %s %s on listener %s mount %s is already registered by module %s", moduleName, route.Method, route.Path, listener, mount, existing.Module)
+				return
+			}
+			for _, previous := range added[:key] {
+				if previous.Method == route.Method && previous.Path == route.Path {
+					e = fmt.Errorf("module %s: route %s %s on listener %s mount %s is registered twice", moduleName, route.Method, route.Path, listener, mount)
+					return
+				}
+			}
+		}
+
+		e = fmt.Errorf("module %s: registering routes on listener %s mount %s: %v", moduleName, listener, mount, rec)
+	}()
+
+	register(routes)
+
+	return nil
+}
+
+func (r *routeTable) sorted() []Route {
+	routes := make([]Route, len(r.routes))
+	copy(routes, r.routes)
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Listener != routes[j].Listener {
+			return routes[i].Listener < routes[j].Listener
+		}
+		if routes[i].Mount.String() != routes[j].Mount.String() {
+			return routes[i].Mount.String() < routes[j].Mount.String()
+		}
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
+}
+
+// Routes returns every route registered when the server was started
+func (s *Server) Routes() []Route {
+	s.routeTableLock.Lock()
+	defer s.routeTableLock.Unlock()
+
+	if s.routeTable == nil {
+		return nil
+	}
+
+	return s.routeTable.sorted()
+}
+
+func (s *Server) RoutesHandler(ctx *fasthttp.RequestCtx) {
+	routes := s.Routes()
+
+	listenerWidth, mountWidth, methodWidth, pathWidth := len("LISTENER"), len("MOUNT"), len("METHOD"), len("PATH")
+	for _, route := range routes {
+		if len(route.Listener) > listenerWidth {
+			listenerWidth = len(route.Listener)
+		}
+		if len(route.Mount.String()) > mountWidth {
+			mountWidth = len(route.Mount.String())
+		}
+		if len(route.Method) > methodWidth {
+			methodWidth = len(route.Method)
+		}
+		if len(route.Path) > pathWidth {
+			pathWidth = len(route.Path)
+		}
+	}
+
+	format := fmt.Sprintf("%%-%ds  %%-%ds  %%-%ds  %%-%ds  %%s\n", listenerWidth, mountWidth, methodWidth, pathWidth)
+
+	ctx.SetContentType("text/plain")
+	_, _ = fmt.Fprintf(ctx, format, "LISTENER", "MOUNT", "METHOD", "PATH", "MODULE")
+	_, _ = fmt.Fprintln(ctx, strings.Repeat("-", listenerWidth+mountWidth+methodWidth+pathWidth+len("MODULE")+8))
+	for _, route := range routes {
+		_, _ = fmt.Fprintf(ctx, format, route.Listener, route.Mount.String(), route.Method, route.Path, route.Module)
+	}
+}
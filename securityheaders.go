@@ -0,0 +1,124 @@
+package cbweb
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/valyala/fasthttp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// CspNoncePlaceholder is replaced with the request's nonce in SecurityHeadersConfig.ContentSecurityPolicy
+	CspNoncePlaceholder = "{nonce}"
+	// DefaultContentSecurityPolicy allows the cdn scripts and fonts used by cbwebcommon's master.gohtml
+	DefaultContentSecurityPolicy = "default-src 'self'; " +
+		"script-src 'self' 'nonce-{nonce}' https://code.jquery.com; " +
+		"style-src 'self' 'nonce-{nonce}' https://fonts.googleapis.com; " +
+		"font-src 'self' https://fonts.gstatic.com; " +
+		"img-src 'self' data:; " +
+		"object-src 'none'; " +
+		"base-uri 'self'; " +
+		"form-action 'self'; " +
+		"frame-ancestors 'none'"
+	cspNonceContextKey = NewContextKey("cspNonce")
+)
+
+type SecurityHeadersConfig struct {
+	// HstsMaxAge enables Strict-Transport-Security, only set it when the site is always served over https
+	HstsMaxAge            time.Duration
+	HstsIncludeSubdomains bool
+	HstsPreload           bool
+	FrameOptions          string
+	ReferrerPolicy        string
+	PermissionsPolicy     string
+	ContentSecurityPolicy string
+	DisableCsp            bool
+	CspReportOnly         bool
+}
+
+type SecurityHeaders struct {
+	config SecurityHeadersConfig
+	hsts   string
+}
+
+func NewSecurityHeaders(config SecurityHeadersConfig) *SecurityHeaders {
+	if config.FrameOptions == "" {
+		config.FrameOptions = "DENY"
+	}
+	if config.ReferrerPolicy == "" {
+		config.ReferrerPolicy = "strict-origin-when-cross-origin"
+	}
+	if config.PermissionsPolicy == "" {
+		config.PermissionsPolicy = "camera=(), microphone=(), geolocation=(), payment=()"
+	}
+	if config.ContentSecurityPolicy == "" {
+		config.ContentSecurityPolicy = DefaultContentSecurityPolicy
+	}
+
+	securityHeaders := &SecurityHeaders{config: config}
+	if config.HstsMaxAge > 0 {
+		securityHeaders.hsts = "max-age=" + strconv.Itoa(int(config.HstsMaxAge/time.Second))
+		if config.HstsIncludeSubdomains {
+			securityHeaders.hsts += "; includeSubDomains"
+		}
+		if config.HstsPreload {
+			securityHeaders.hsts += "; preload"
+		}
+	}
+
+	return securityHeaders
+}
+
+func (s *SecurityHeaders) Middleware(ctx *fasthttp.RequestCtx) (bool, error) {
+	if s.hsts != "" {
+		ctx.Response.Header.Set("Strict-Transport-Security", s.hsts)
+	}
+	ctx.Response.Header.Set("X-Frame-Options", s.config.FrameOptions)
+	ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+	ctx.Response.Header.Set("Referrer-Policy", s.config.ReferrerPolicy)
+	ctx.Response.Header.Set("Permissions-Policy", s.config.PermissionsPolicy)
+
+	if s.config.DisableCsp {
+		return true, nil
+	}
+
+	policy := s.config.ContentSecurityPolicy
+	if strings.Contains(policy, CspNoncePlaceholder) {
+		nonce := GetCspNonce(ctx)
+		if nonce == "" {
+			nonce = newCspNonce()
+			cspNonceContextKey.Set(ctx, nonce)
+		}
+		policy = strings.Replace(policy, CspNoncePlaceholder, nonce, -1)
+	}
+
+	if s.config.CspReportOnly {
+		ctx.Response.Header.Set("Content-Security-Policy-Report-Only", policy)
+	} else {
+		ctx.Response.Header.Set("Content-Security-Policy", policy)
+	}
+
+	return true, nil
+}
+
+// GetCspNonce returns the nonce allowed by this request's policy, NewMasterViewModel sets it as DefaultMasterViewModel.CspNonce
+// so master.gohtml adds it to every inline script and style
+func GetCspNonce(ctx *fasthttp.RequestCtx) string {
+	if nonce, ok := cspNonceContextKey.Get(ctx).(string); ok {
+		return nonce
+	}
+
+	return ""
+}
+
+func newCspNonce() string {
+	nonce := make([]byte, 16)
+	_, e := rand.Read(nonce)
+	if e != nil {
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(nonce)
+}
@@ -0,0 +1,165 @@
+package cbweb
+
+import (
+	"fmt"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"sort"
+	"sync"
+)
+
+var (
+	MiddlewareStack_Html    = "html"
+	MiddlewareStack_JsonApi = "json-api"
+	// DefaultMiddlewareStacks is used by modules which are not given their own registry
+	DefaultMiddlewareStacks = NewMiddlewareStacks()
+)
+
+// MiddlewareStacks is a registry of named MiddlewareHandlers without a final handler, so the limiter,
+// error handler and middleware of a stack are configured once and shared by every route using it
+type MiddlewareStacks struct {
+	stacks map[string]MiddlewareHandler
+	lock   sync.RWMutex
+}
+
+func NewMiddlewareStacks() *MiddlewareStacks {
+	stacks := &MiddlewareStacks{stacks: make(map[string]MiddlewareHandler)}
+	stacks.Set(MiddlewareStack_Html, MiddlewareHandler{}.AddMiddleware(HtmlMiddleware))
+	stacks.Set(MiddlewareStack_JsonApi, MiddlewareHandler{}.AddMiddleware(JsonMiddleware))
+
+	return stacks
+}
+
+func (s *MiddlewareStacks) Set(name string, stack MiddlewareHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stacks[name] = stack
+}
+
+func (s *MiddlewareStacks) Get(name string) (MiddlewareHandler, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stack, ok := s.stacks[name]
+
+	return stack, ok
+}
+
+// MustGet panics for unknown stacks, when called from SetRoutes the server reports it as a startup error
+func (s *MiddlewareStacks) MustGet(name string) MiddlewareHandler {
+	stack, ok := s.Get(name)
+	if !ok {
+		panic(fmt.Sprintf("unknown middleware stack %s", name))
+	}
+
+	return stack
+}
+
+func (s *MiddlewareStacks) Names() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var names []string
+	for name := range s.stacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Group returns a RouteGroup on routes which wraps every handler in the named stack
+func (s *MiddlewareStacks) Group(routes *router.Router, prefix string, name string) *RouteGroup {
+	return NewRouteGroup(routes, prefix, s.MustGet(name))
+}
+
+type RouteGroup struct {
+	routes *router.Router
+	prefix string
+	stack  MiddlewareHandler
+	cors   *Cors
+}
+
+func NewRouteGroup(routes *router.Router, prefix string, stack MiddlewareHandler) *RouteGroup {
+	return &RouteGroup{
+		routes: routes,
+		prefix: prefix,
+		stack:  stack,
+	}
+}
+
+// Group returns a nested group sharing this group's stack
+func (g *RouteGroup) Group(prefix string) *RouteGroup {
+	group := NewRouteGroup(g.routes, g.prefix+prefix, g.stack)
+	group.cors = g.cors
+
+	return group
+}
+
+// With returns a group on the same prefix with extra middleware appended to the stack
+func (g *RouteGroup) With(middleware ...func(ctx *fasthttp.RequestCtx) (bool, error)) *RouteGroup {
+	group := NewRouteGroup(g.routes, g.prefix, g.stack.AddMiddleware(middleware...))
+	group.cors = g.cors
+
+	return group
+}
+
+// WithCors returns a group on the same prefix which registers its routes with Cors.Handle,
+// so every path also answers the OPTIONS preflight
+func (g *RouteGroup) WithCors(cors *Cors) *RouteGroup {
+	group := NewRouteGroup(g.routes, g.prefix, g.stack)
+	group.cors = cors
+
+	return group
+}
+
+func (g *RouteGroup) GetStack() MiddlewareHandler {
+	return g.stack
+}
+
+// Handler wraps final in the group's stack, including its limiter
+func (g *RouteGroup) Handler(final func(ctx *fasthttp.RequestCtx)) fasthttp.RequestHandler {
+	return g.stack.SetFinal(final).HandleLimited()
+}
+
+func (g *RouteGroup) Handle(method, path string, final func(ctx *fasthttp.RequestCtx)) {
+	if g.prefix != "" && path == "/" {
+		path = g.prefix
+	} else {
+		path = g.prefix + path
+	}
+	if g.cors != nil {
+		g.cors.Handle(g.routes, method, path, g.Handler(final))
+		return
+	}
+	g.routes.Handle(method, path, g.Handler(final))
+}
+
+func (g *RouteGroup) GET(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodGet, path, final)
+}
+
+func (g *RouteGroup) HEAD(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodHead, path, final)
+}
+
+func (g *RouteGroup) POST(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodPost, path, final)
+}
+
+func (g *RouteGroup) PUT(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodPut, path, final)
+}
+
+func (g *RouteGroup) PATCH(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodPatch, path, final)
+}
+
+func (g *RouteGroup) DELETE(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodDelete, path, final)
+}
+
+func (g *RouteGroup) OPTIONS(path string, final func(ctx *fasthttp.RequestCtx)) {
+	g.Handle(fasthttp.MethodOptions, path, final)
+}
@@ -31,12 +31,15 @@ type InheritanceMultiTemplate struct {
 	funcs           template.FuncMap
 	cache           bool
 	cachedTemplates cbweb.CacheProvider
+	cacheKeyPrefix  string
 }
 
 type Dependencies struct {
 	Funcs         template.FuncMap
 	Cache         bool
 	CacheProvider cbweb.CacheProvider
+	// CacheKeyPrefix keeps templates parsed with different Funcs apart when they share a CacheProvider
+	CacheKeyPrefix string
 }
 
 func NewInheritanceMultiTemplate(dependencies Dependencies) *InheritanceMultiTemplate {
@@ -45,6 +48,7 @@ func NewInheritanceMultiTemplate(dependencies Dependencies) *InheritanceMultiTem
 		funcs:           dependencies.Funcs,
 		cache:           dependencies.Cache,
 		cachedTemplates: dependencies.CacheProvider,
+		cacheKeyPrefix:  dependencies.CacheKeyPrefix,
 	}
 }
 
@@ -92,7 +96,7 @@ func (m *InheritanceMultiTemplate) AddTemplate(name string, content []byte) erro
 func (m *InheritanceMultiTemplate) ExecuteTemplate(wr io.Writer, name string, data interface{}) error {
 	var t *template.Template
 	var ok bool
-	cacheKey := "executeTemplate:" + name
+	cacheKey := m.cacheKeyPrefix + "executeTemplate:" + name
 	if m.cachedTemplates != nil {
 		var cache interface{}
 		cache, ok = m.cachedTemplates.Get(cacheKey)
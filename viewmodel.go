@@ -1,6 +1,9 @@
 package cbweb
 
-import "html/template"
+import (
+	"github.com/valyala/fasthttp"
+	"html/template"
+)
 
 type ViewIncludeType string
 
@@ -71,6 +74,8 @@ type ExecutableViewModel interface {
 	GetMainTemplate() string
 }
 
+// DefaultMasterViewModel is what master.gohtml renders, build it with NewMasterViewModel so the request's flash
+// messages, csrf token and csp nonce are filled in
 type DefaultMasterViewModel struct {
 	ViewIncludes []ViewInclude
 	Title        string
@@ -79,6 +84,17 @@ type DefaultMasterViewModel struct {
 	NavItems     []NavItem
 	Path         template.URL
 	Flash        *Flash
+	Csrf         CsrfToken
+	CspNonce     string
+}
+
+// NewMasterViewModel fills in what the middleware stored on the request: GetFlash, GetCsrfToken and GetCspNonce
+func NewMasterViewModel(ctx *fasthttp.RequestCtx) DefaultMasterViewModel {
+	return DefaultMasterViewModel{
+		Flash:    GetFlash(ctx),
+		Csrf:     GetCsrfToken(ctx),
+		CspNonce: GetCspNonce(ctx),
+	}
 }
 
 func (m DefaultMasterViewModel) GetViewIncludes() []ViewInclude {
@@ -112,6 +128,20 @@ func (m DefaultMasterViewModel) GetFlash() *Flash {
 	return m.Flash
 }
 
+func (m DefaultMasterViewModel) GetCsrf() CsrfToken {
+	return m.Csrf
+}
+
+// CsrfField renders the hidden csrf input, add {{ .GetMasterViewModel.CsrfField }} inside every form which posts.
+// Forms built with NewForm already include it
+func (m DefaultMasterViewModel) CsrfField() template.HTML {
+	return m.Csrf.Input()
+}
+
+func (m DefaultMasterViewModel) GetCspNonce() string {
+	return m.CspNonce
+}
+
 func (h ViewIncludeType) IsJsHead() bool {
 	return h == ViewIncludeType_JsHead
 }
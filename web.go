@@ -1,12 +1,19 @@
 package cbweb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
+	"net"
 	"os"
 	"os/signal"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
 type Module interface {
@@ -17,17 +24,64 @@ type Module interface {
 
 type Server struct {
 	port               string
+	tlsCertFile        string
+	tlsKeyFile         string
+	listeners          []Listener
+	certificates       []*certificateReloader
+	certificatesLock   sync.Mutex
 	maxRequestBodySize int
+	idleTimeout        time.Duration
+	shutdownTimeout    time.Duration
+	drainDelay         time.Duration
+	readinessPath      string
+	healthCheckPath    string
+	exposeRoutes       bool
 	modules            []Module
+	moduleMounts       []Mount
 	errorHandler       ErrorHandler
 	globalMiddleware   *MiddlewareHandler
+	accessLog          *AccessLog
+	metrics            *Metrics
+	requestId          bool
+	requestLimitRules  requestLimitRules
+	shutdownHooks      []func(ctx context.Context) error
+	startedModules     []Module
+	startedModulesLock sync.Mutex
+	routeTable         *routeTable
+	routeTableLock     sync.Mutex
+	httpServers        []*fasthttp.Server
+	httpServerLock     sync.Mutex
+	stopping           bool
+	ready              int32
+	shutdownOnce       sync.Once
+	shutdownError      error
 }
 
 type Dependencies struct {
 	Port               string
+	TLSCertFile        string
+	TLSKeyFile         string
+	Listeners          []Listener
 	MaxRequestBodySize int
 	ErrorHandler       ErrorHandler
 	GlobalMiddleware   *MiddlewareHandler
+	AccessLog          *AccessLog
+	// Metrics counts and times every request, serve it with the cbwebmetrics module
+	Metrics *Metrics
+	// RequestId runs RequestIdMiddleware on every request before anything else
+	RequestId bool
+	// RequestLimitRules override the body size, timeouts and handler deadline for matching paths
+	RequestLimitRules []RequestLimitRule
+	// IdleTimeout bounds how long keep-alive connections may hold up a drain
+	IdleTimeout time.Duration
+	// ShutdownTimeout is used by RunAndCatch when draining, zero waits forever
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the server reports not ready before it stops accepting connections
+	DrainDelay      time.Duration
+	ReadinessPath   string
+	HealthCheckPath string
+	// ExposeRoutes serves the route table at RoutesPath, it is intended for dev environments only
+	ExposeRoutes bool
 }
 
 func NewServer(dependencies Dependencies, modules ...Module) *Server {
@@ -36,60 +90,406 @@ func NewServer(dependencies Dependencies, modules ...Module) *Server {
 	}
 	return &Server{
 		port:               dependencies.Port,
+		tlsCertFile:        dependencies.TLSCertFile,
+		tlsKeyFile:         dependencies.TLSKeyFile,
+		listeners:          dependencies.Listeners,
 		maxRequestBodySize: dependencies.MaxRequestBodySize,
+		idleTimeout:        dependencies.IdleTimeout,
+		shutdownTimeout:    dependencies.ShutdownTimeout,
+		drainDelay:         dependencies.DrainDelay,
+		readinessPath:      dependencies.ReadinessPath,
+		healthCheckPath:    dependencies.HealthCheckPath,
+		exposeRoutes:       dependencies.ExposeRoutes,
 		errorHandler:       dependencies.ErrorHandler,
 		globalMiddleware:   dependencies.GlobalMiddleware,
+		accessLog:          dependencies.AccessLog,
+		metrics:            dependencies.Metrics,
+		requestId:          dependencies.RequestId,
+		requestLimitRules:  newRequestLimitRules(dependencies.RequestLimitRules),
 		modules:            modules,
+		moduleMounts:       make([]Mount, len(modules)),
 	}
 }
 
 func (s *Server) AddModule(module Module) {
 	s.modules = append(s.modules, module)
+	s.moduleMounts = append(s.moduleMounts, Mount{})
+}
+
+// GetMetrics returns Dependencies.Metrics, which is nil when metrics are not enabled
+func (s *Server) GetMetrics() *Metrics {
+	return s.metrics
+}
+
+func (s *Server) AddListener(listener Listener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *Server) AddShutdownHook(hook func(ctx context.Context) error) {
+	s.shutdownHooks = append(s.shutdownHooks, hook)
+}
+
+func (s *Server) IsReady() bool {
+	return atomic.LoadInt32(&s.ready) == 1
+}
+
+func (s *Server) ReadinessHandler(ctx *fasthttp.RequestCtx) {
+	ctx.SetContentType("text/plain")
+	if s.IsReady() {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		ctx.SetBodyString("ready")
+		return
+	}
+
+	ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+	ctx.SetBodyString("not ready")
+}
+
+func (s *Server) HealthCheckHandler(ctx *fasthttp.RequestCtx) {
+	health := s.HealthCheck()
+
+	var names []string
+	for name := range health {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx.SetContentType("text/plain")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	for _, name := range names {
+		if health[name] != nil {
+			ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(ctx, "%s: %s\n", name, health[name].Error())
+		} else {
+			_, _ = fmt.Fprintf(ctx, "%s: ok\n", name)
+		}
+	}
 }
 
 func (s *Server) Start() error {
-	routes := router.New()
-	routes.RedirectTrailingSlash = false
-	routes.RedirectFixedPath = false
+	order, e := orderModules(s.modules)
+	if e != nil {
+		return e
+	}
+
+	modules := make([]Module, len(order))
+	mounts := make([]Mount, len(order))
+	for key, moduleKey := range order {
+		modules[key] = s.modules[moduleKey]
+		mounts[key] = s.moduleMounts[moduleKey]
+	}
 
-	globalTemplates := make(map[string][]byte)
+	e = s.initModules(modules)
+	if e != nil {
+		return e
+	}
 
-	for _, module := range s.modules {
-		module.SetRoutes(routes)
-		for templateName, templateBytes := range module.GetGlobalTemplates() {
-			globalTemplates[templateName] = templateBytes
+	listeners, e := s.getListeners()
+	if e != nil {
+		s.stopInitialisedModules(modules)
+		return e
+	}
+
+	s.setGlobalTemplates(modules, mounts)
+
+	table := &routeTable{}
+	servers := make([]*fasthttp.Server, len(listeners))
+	for key, listener := range listeners {
+		handler, e := s.buildHandler(table, listener, modules, mounts)
+		if e != nil {
+			s.stopInitialisedModules(modules)
+			return e
 		}
+		servers[key] = s.newHttpServer(handler)
 	}
 
-	for _, module := range s.modules {
-		module.SetGlobalTemplates(globalTemplates)
+	s.routeTableLock.Lock()
+	s.routeTable = table
+	s.routeTableLock.Unlock()
+
+	e = s.startModules(modules)
+	if e != nil {
+		s.stopModulesAfterFailedStart()
+		return e
 	}
 
-	server := fasthttp.Server{
-		MaxRequestBodySize: s.maxRequestBodySize,
-		Handler: func(ctx *fasthttp.RequestCtx) {
-			defer func() {
-				rec := recover()
-				if rec != nil {
-					if e, ok := rec.(error); ok {
-						s.errorHandler.Error(e)
-					} else {
-						s.errorHandler.Error(errors.New(fmt.Sprint(rec)))
-					}
-					ctx.Response.SetStatusCode(500)
+	netListeners := make([]net.Listener, len(listeners))
+	for key, listener := range listeners {
+		netListeners[key], e = s.listen(listener)
+		if e != nil {
+			for _, netListener := range netListeners[:key] {
+				_ = netListener.Close()
+			}
+			s.stopModulesAfterFailedStart()
+			return fmt.Errorf("listener %s: %w", listener.getName(), e)
+		}
+	}
+
+	s.httpServerLock.Lock()
+	if s.stopping {
+		s.httpServerLock.Unlock()
+		for _, netListener := range netListeners {
+			_ = netListener.Close()
+		}
+		return s.stopModules(context.Background())
+	}
+	s.httpServers = servers
+	s.httpServerLock.Unlock()
+
+	stopReloading := s.reloadCertificatesOnHangup()
+	defer stopReloading()
+
+	atomic.StoreInt32(&s.ready, 1)
+	defer atomic.StoreInt32(&s.ready, 0)
+
+	served := make(chan error, len(servers))
+	for key := range servers {
+		go func(server *fasthttp.Server, netListener net.Listener) {
+			served <- server.Serve(netListener)
+		}(servers[key], netListeners[key])
+	}
+
+	var serveError error
+	for range servers {
+		e := <-served
+		if e != nil && serveError == nil {
+			serveError = e
+			atomic.StoreInt32(&s.ready, 0)
+			for _, server := range servers {
+				go func(server *fasthttp.Server) {
+					_ = server.Shutdown()
+				}(server)
+			}
+		}
+	}
+
+	if serveError != nil {
+		s.stopModulesAfterFailedStart()
+	}
+
+	return serveError
+}
+
+func (s *Server) stopModulesAfterFailedStart() {
+	e := s.stopModules(context.Background())
+	if e != nil {
+		s.errorHandler.Error(e)
+	}
+}
+
+func (s *Server) reloadCertificatesOnHangup() func() {
+	s.certificatesLock.Lock()
+	hasCertificates := len(s.certificates) > 0
+	s.certificatesLock.Unlock()
+
+	if !hasCertificates {
+		return func() {}
+	}
+
+	hangup := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-hangup:
+				e := s.ReloadCertificates()
+				if e != nil {
+					s.errorHandler.Error(e)
 				}
-			}()
-			if s.globalMiddleware == nil {
-				routes.Handler(ctx)
-			} else {
-				s.globalMiddleware.SetFinal(routes.Handler).HandleLimited()(ctx)
+			case <-done:
+				return
 			}
-		},
+		}
+	}()
+
+	return func() {
+		signal.Stop(hangup)
+		close(done)
 	}
+}
 
-	e := server.ListenAndServe(s.port)
+func (s *Server) newHttpServer(handler fasthttp.RequestHandler) *fasthttp.Server {
+	handle := func(ctx *fasthttp.RequestCtx) {
+		if s.requestId {
+			_, _ = RequestIdMiddleware(ctx)
+		}
+		defer func() {
+			rec := recover()
+			if rec != nil {
+				if e, ok := rec.(error); ok {
+					HandleError(s.errorHandler, ctx, e)
+				} else {
+					HandleError(s.errorHandler, ctx, errors.New(fmt.Sprint(rec)))
+				}
+				if s.metrics != nil {
+					s.metrics.IncrementCounter(MetricPanicsRecovered, nil, 1)
+				}
+				ctx.Response.SetStatusCode(500)
+			}
+		}()
+		handle := handler
+		if s.globalMiddleware != nil {
+			handle = s.globalMiddleware.SetFinal(handler).HandleLimited()
+		}
+		if limits, ok := s.requestLimitRules.find(ctx.Method(), ctx.Path()); ok {
+			limits.Timeout(ctx, s.errorHandler, handle)
+			return
+		}
+		handle(ctx)
+	}
 
-	return e
+	if s.metrics != nil {
+		handle = s.metrics.Instrument(handle)
+	}
+	if s.accessLog != nil {
+		handle = s.accessLog.Handler(handle)
+	}
+
+	server := &fasthttp.Server{
+		MaxRequestBodySize: s.maxRequestBodySize,
+		IdleTimeout:        s.idleTimeout,
+		CloseOnShutdown:    true,
+		Handler:            handle,
+		ErrorHandler:       readErrorHandler,
+	}
+	if len(s.requestLimitRules) > 0 {
+		server.HeaderReceived = s.requestLimitRules.headerReceived
+	}
+
+	return server
+}
+
+// setGlobalTemplates gives every module the global templates of the root mount,
+// overridden by the global templates of its own mount
+func (s *Server) setGlobalTemplates(modules []Module, mounts []Mount) {
+	globalTemplates := make(map[Mount]map[string][]byte)
+	for key, module := range modules {
+		if globalTemplates[mounts[key]] == nil {
+			globalTemplates[mounts[key]] = make(map[string][]byte)
+		}
+		for templateName, templateBytes := range module.GetGlobalTemplates() {
+			globalTemplates[mounts[key]][templateName] = templateBytes
+		}
+	}
+
+	for key, module := range modules {
+		scopedTemplates := make(map[string][]byte)
+		for templateName, templateBytes := range globalTemplates[Mount{}] {
+			scopedTemplates[templateName] = templateBytes
+		}
+		if !mounts[key].IsRoot() {
+			for templateName, templateBytes := range globalTemplates[mounts[key]] {
+				scopedTemplates[templateName] = templateBytes
+			}
+		}
+		module.SetGlobalTemplates(scopedTemplates)
+	}
+}
+
+func (s *Server) buildHandler(table *routeTable, listener Listener, modules []Module, mounts []Mount) (fasthttp.RequestHandler, error) {
+	root := newRouter()
+	routers := map[Mount]*router.Router{{}: root}
+	var mounted []mountRouter
+
+	for key, module := range modules {
+		if !listener.serves(module) {
+			continue
+		}
+		mount := mounts[key]
+		routes, ok := routers[mount]
+		if !ok {
+			routes = newRouter()
+			routers[mount] = routes
+			mounted = append(mounted, mountRouter{mount: mount, routes: routes})
+		}
+		e := table.register(listener.getName(), GetModuleName(module), mount, routes, module.SetRoutes)
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	e := table.register(listener.getName(), "cbweb", Mount{}, root, func(routes *router.Router) {
+		if s.readinessPath != "" {
+			routes.GET(s.readinessPath, s.ReadinessHandler)
+		}
+		if s.healthCheckPath != "" {
+			routes.GET(s.healthCheckPath, s.HealthCheckHandler)
+		}
+		if s.exposeRoutes {
+			routes.GET(RoutesPath, s.RoutesHandler)
+		}
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	return mountHandler(root, mounted), nil
+}
+
+// Shutdown marks the server as not ready, waits for the drain delay, stops accepting new connections
+// and waits for in-flight requests to finish before running the shutdown hooks.
+// Only the first call does any work, later calls return the same result.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.shutdownError = s.shutdown(ctx)
+	})
+
+	return s.shutdownError
+}
+
+func (s *Server) shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&s.ready, 0)
+
+	if s.drainDelay > 0 {
+		select {
+		case <-time.After(s.drainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	var shutdownError error
+
+	s.httpServerLock.Lock()
+	s.stopping = true
+	servers := s.httpServers
+	s.httpServerLock.Unlock()
+
+	shutdownErrors := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *fasthttp.Server) {
+			shutdownErrors <- server.ShutdownWithContext(ctx)
+		}(server)
+	}
+	for range servers {
+		e := <-shutdownErrors
+		if e != nil && shutdownError == nil {
+			shutdownError = e
+		}
+	}
+
+	e := s.stopModules(ctx)
+	if e != nil {
+		if shutdownError == nil {
+			shutdownError = e
+		} else {
+			s.errorHandler.Error(e)
+		}
+	}
+
+	for _, hook := range s.shutdownHooks {
+		e := hook(ctx)
+		if e != nil {
+			if shutdownError == nil {
+				shutdownError = e
+			} else {
+				s.errorHandler.Error(e)
+			}
+		}
+	}
+
+	return shutdownError
 }
 
 func (s *Server) RunAndCatch(catch map[os.Signal]func()) {
@@ -111,4 +511,16 @@ func (s *Server) RunAndCatch(catch map[os.Signal]func()) {
 	if fun, ok := catch[sig]; ok && fun != nil {
 		fun()
 	}
+
+	ctx := context.Background()
+	if s.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.shutdownTimeout)
+		defer cancel()
+	}
+
+	e := s.Shutdown(ctx)
+	if e != nil {
+		s.errorHandler.Error(e)
+	}
 }